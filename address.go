@@ -1,7 +1,6 @@
 package godress
 
 import (
-	"crypto/md5"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -9,7 +8,7 @@ import (
 )
 
 const (
-	smallestZipCode    = "01001"
+	smallestZipCode    = "00501"
 	largestZipCode     = "99950"
 	numberRegexPattern = `(?m)(\d+)`
 )
@@ -19,154 +18,111 @@ var (
 )
 
 // Address represents a street address' parts.
+//
+// Urbanization is the neighborhood of a Puerto Rico address, i.e. LAS
+// GLADIOLAS in URB LAS GLADIOLAS 150 CALLE A, where street names repeat.
+//
+// HouseNumber is the full house number, i.e. 37-40 or 123 1/2, and the
+// fields after it hold its parts: the number itself, a letter after it, a
+// fraction and the end of a range.
+//
+// PreDirectional and PostDirectional are the directions before and after
+// the street name, i.e. N in 123 N Main St and E in 800 E.
+//
+// StreetPreType is a street type written before the name, like CALLE in
+// Calle Sol, or a highway designator in its USPS standard form, like
+// COUNTY ROAD in CR 12.
+//
+// GridCoordinate and GridDirection are set for grid addresses, like the
+// 800 and E of 137 N 800 E in Utah, which are also the street name and
+// post directional. GridDirection is always abbreviated, so 800 East,
+// 800 E and 800 E St are the same street.
+//
+// Kind tells street addresses from PO boxes, rural or highway contract
+// routes and military addresses. Routes have no street, only a Route and
+// Box number, military addresses have a MilitaryUnitType like PSC, a
+// MilitaryUnit and a Box, and PO boxes have their number in both
+// HouseNumber and Box.
+//
+// PostalCode is the 5 digit zip code, PostalCodeExt its +4 add-on and
+// DeliveryPoint the 2 digits that may follow that.
+//
+// Inferred lists the fields that were filled in by Complete or Enrich
+// instead of parsed, and ZipSuggestions the zip codes that fit the city and
+// state of an address without one.
+//
+// Corrections lists the misspellings a lenient Parser fixed, i.e. Stret
+// for STREET or Lehhi for LEHI.
+//
+// Units holds every secondary unit in order, i.e. BLDG 3 then APT 12.
+// UnitDesignator and Unit hold the last, most specific, one.
 type Address struct {
-	Hash            string  `arango:"hash" json:"hash"`
-	Original        string  `arango:"original" json:"original"`
-	HouseNumber     string  `arango:"house_number" json:"house_number"`
-	StreetDirection string  `arango:"street_direction" json:"street_direction"`
-	StreetName      string  `arango:"street_name" json:"street_name"`
-	StreetType      string  `arango:"street_type" json:"street_type"`
-	Unit            string  `arango:"unit" json:"unit"`
-	City            string  `arango:"city" json:"city"`
-	County          string  `arango:"county" json:"county"`
-	State           string  `arango:"state" json:"state"`
-	PostalCode      string  `arango:"postal_code" json:"postal_code"`
-	Country         string  `arango:"country" json:"country"`
-	Latitude        float64 `arango:"latitude,omitempty" json:"latitude,omitempty"`
-	Longitude       float64 `arango:"longitude,omitempty" json:"longitude,omitempty"`
+	Kind                AddressKind `arango:"kind" json:"kind"`
+	Hash                string      `arango:"hash" json:"hash"`
+	Original            string      `arango:"original" json:"original"`
+	Urbanization        string      `arango:"urbanization,omitempty" json:"urbanization,omitempty"`
+	HouseNumber         string      `arango:"house_number" json:"house_number"`
+	HouseNumberBase     string      `arango:"house_number_base" json:"house_number_base"`
+	HouseNumberSuffix   string      `arango:"house_number_suffix" json:"house_number_suffix"`
+	HouseNumberFraction string      `arango:"house_number_fraction" json:"house_number_fraction"`
+	HouseNumberRangeEnd string      `arango:"house_number_range_end" json:"house_number_range_end"`
+	PreDirectional      string      `arango:"pre_directional" json:"pre_directional"`
+	// StreetDirection holds whichever of PreDirectional and
+	// PostDirectional is set, preferring the first.
+	//
+	// Deprecated: Use PreDirectional and PostDirectional instead.
+	StreetDirection  string          `arango:"street_direction" json:"street_direction"`
+	StreetPreType    string          `arango:"street_pre_type" json:"street_pre_type"`
+	StreetName       string          `arango:"street_name" json:"street_name"`
+	StreetType       string          `arango:"street_type" json:"street_type"`
+	PostDirectional  string          `arango:"post_directional" json:"post_directional"`
+	GridCoordinate   string          `arango:"grid_coordinate,omitempty" json:"grid_coordinate,omitempty"`
+	GridDirection    string          `arango:"grid_direction,omitempty" json:"grid_direction,omitempty"`
+	UnitDesignator   string          `arango:"unit_designator" json:"unit_designator"`
+	Unit             string          `arango:"unit" json:"unit"`
+	Units            []SecondaryUnit `arango:"units,omitempty" json:"units,omitempty"`
+	City             string          `arango:"city" json:"city"`
+	County           string          `arango:"county" json:"county"`
+	State            string          `arango:"state" json:"state"`
+	Route            string          `arango:"route,omitempty" json:"route,omitempty"`
+	MilitaryUnitType string          `arango:"military_unit_type,omitempty" json:"military_unit_type,omitempty"`
+	MilitaryUnit     string          `arango:"military_unit,omitempty" json:"military_unit,omitempty"`
+	Box              string          `arango:"box,omitempty" json:"box,omitempty"`
+	PostalCode       string          `arango:"postal_code" json:"postal_code"`
+	PostalCodeExt    string          `arango:"postal_code_ext,omitempty" json:"postal_code_ext,omitempty"`
+	DeliveryPoint    string          `arango:"delivery_point,omitempty" json:"delivery_point,omitempty"`
+	Country          string          `arango:"country" json:"country"`
+	Inferred         []Field         `arango:"inferred,omitempty" json:"inferred,omitempty"`
+	ZipSuggestions   []string        `arango:"zip_suggestions,omitempty" json:"zip_suggestions,omitempty"`
+	Corrections      []Correction    `arango:"corrections,omitempty" json:"corrections,omitempty"`
+	Latitude         float64         `arango:"latitude,omitempty" json:"latitude,omitempty"`
+	Longitude        float64         `arango:"longitude,omitempty" json:"longitude,omitempty"`
 }
 
-// MustParse parses the address, ignoring any errors.
+// MustParse parses the address, ignoring any errors. The returned address
+// may be partially populated when the input could not be fully parsed.
 func MustParse(address string) (addr *Address) {
 	addr, _ = Parse(address)
 
 	return addr
 }
 
-// Parse parses a string into an address struct.
+// Parse parses a string into an address struct. When the input can't be
+// parsed a *ParseError is returned along with whatever parts were found.
 func Parse(address string) (a *Address, err error) {
-	stripped := regexp.MustCompile(`/\s+/ /`).ReplaceAllString(address, "")
-	stripped = regexp.MustCompile(`\.`).ReplaceAllString(stripped, "")
-	stripped = strings.ToUpper(stripped)
-
-	a = &Address{Original: stripped}
-	a.Hash = fmt.Sprintf("%x", md5.Sum([]byte(stripped)))
-
-	x := strings.FieldsFunc(stripped, split)
-
-	if IsPoBox(address) {
-		a, err = parsePoBox(a, x)
-
-		return
-	}
-
-	var (
-		currentValue string
-		cityWords    []string
-	)
-
-	for i := 0; i < len(x); i++ {
-		currentValue = x[i]
-		if i == 0 {
-			if isInt(currentValue) {
-				a.HouseNumber = currentValue
-			}
-		} else if IsStreetDirection(currentValue) && a.StreetDirection == "" {
-			a.StreetDirection = currentValue
-		} else if len(cityWords) == 0 && IsStreetType(currentValue) && a.Unit == "" {
-			a.StreetType = currentValue
-		} else if isApartmentKeyword(currentValue) && a.Unit == "" {
-			if i+1 < len(x) {
-				a.Unit = x[i+1]
-				i++
-			}
-		} else if IsState(currentValue) {
-			a.State = StateAbbreviation(currentValue)
-		} else if IsZipcode(currentValue) && a.State != "" && a.PostalCode == "" {
-			a.PostalCode = strings.Split(currentValue, "-")[0]
-		} else if (a.StreetDirection != "" || i == 1) && a.StreetType == "" && a.Unit == "" && (i > 0 && !IsStreetDirection(strings.Split(strings.TrimSpace(a.StreetName), " ")[len(strings.Split(strings.TrimSpace(a.StreetName), " "))-1])) && a.City == "" {
-			a.StreetName += (currentValue + " ")
-		} else if a.State == "" && len(currentValue) >= 2 {
-			cityWords = append(cityWords, currentValue)
-		} else if a.StreetType != "" && a.StreetName == "" {
-			a.StreetName += currentValue
-		}
-	}
-
-	a.StreetName = strings.TrimRight(a.StreetName, " ")
-	a.City = strings.Join(cityWords, " ")
-
-	return
-}
-
-func parsePoBox(a *Address, x []string) (*Address, error) {
-	var (
-		currentValue string
-		cityWords    []string
-	)
-
-	a.StreetName = "PO BOX"
-
-	for i := 0; i < len(x); i++ {
-		currentValue = x[i]
-		if currentValue == "PO" || currentValue == "BOX" {
-			continue
-		}
-
-		if a.HouseNumber == "" && isInt(currentValue) {
-			a.HouseNumber = currentValue
-		} else if IsState(currentValue) && len(currentValue) == 2 {
-			a.State = currentValue
-		} else if IsZipcode(currentValue) && a.State != "" && a.PostalCode == "" {
-			a.PostalCode = strings.Split(currentValue, "-")[0]
-		} else if len(currentValue) >= 2 {
-			cityWords = append(cityWords, currentValue)
-		}
-	}
-	a.City = strings.Join(cityWords, " ")
-
-	return a, nil
+	return defaultParser.Parse(address)
 }
 
 // Extract attempts to extract an address from a string with surrounding words.
 func Extract(in string) string {
-	addressMinCharacters := 2
-	s := strings.Replace(in, "  ", " ", -1)
-	x := strings.Split(s, " ")
-
-	for i := 0; i <= len(x); i++ {
-		str := func(words []string, offset int) string {
-
-			loops := len(words) - offset
-			for i := 0; i < loops; i++ {
-				// Enough words for an address
-				if len(words[i:loops]) > addressMinCharacters {
-					str := strings.Join(words[i:loops], " ")
-					addr, _ := Parse(str)
-					if addr.StreetType != "" && addr.HouseNumber != "" {
-						return str
-					}
-				}
-			}
-
-			return ""
-		}(x, i)
-
-		if str != "" {
-			return str
-		}
-	}
-
-	return ""
+	return defaultParser.Extract(in)
 }
 
 // IsApartment checks an address string for indication of apt number.
 func IsApartment(s string) bool {
-	sX := strings.FieldsFunc(s, split)
-
-	for _, s = range sX {
-		s = strings.ToLower(strings.TrimSpace(s))
-		if _, ok := unitTerms[s]; ok {
+	l := defaultParser.label(tokenize(s), false, labelOptions{})
+	for _, t := range l.tokens {
+		if t.label == labelUnitDesignator {
 			return true
 		}
 	}
@@ -182,78 +138,129 @@ func IsPoBox(s string) bool {
 }
 
 // IsZipcode determines if a string is a valid zip code
-// or not. (trailing +4 is removed)
+// or not. The +4 add-on and delivery point may follow it, hyphenated,
+// spaced or run together, i.e. 84043-1234, 84043 1234 or 840431234.
 func IsZipcode(s string) bool {
-	s = strings.Split(s, "-")[0]
+	zip, ext, dp := splitZipcode(s)
+	if !isDigits(zip) || len(zip) != 5 || zip < smallestZipCode || zip > largestZipCode {
+		return false
+	} else if ext != "" && (!isDigits(ext) || len(ext) != 4) {
+		return false
+	} else if dp != "" && (ext == "" || !isDigits(dp) || len(dp) != 2) {
+		return false
+	}
+
+	return len(strings.FieldsFunc(s, isZipcodeSeparator)) <= 3
+}
 
-	if isInt(s) && len(s) == 5 && s >= smallestZipCode && s <= largestZipCode {
-		return true
+// splitZipcode splits a zip code into its 5 digits, +4 add-on and
+// delivery point, in any of the forms IsZipcode accepts.
+func splitZipcode(s string) (zip, ext, dp string) {
+	parts := strings.FieldsFunc(s, isZipcodeSeparator)
+	if len(parts) == 1 && isDigits(parts[0]) && (len(parts[0]) == 9 || len(parts[0]) == 11) {
+		parts = []string{parts[0][:5], parts[0][5:9], parts[0][9:]}
 	}
 
-	return false
+	switch len(parts) {
+	case 0:
+		return
+	case 1:
+		return parts[0], "", ""
+	case 2:
+		return parts[0], parts[1], ""
+	}
+
+	return parts[0], parts[1], parts[2]
+}
+
+func isZipcodeSeparator(r rune) bool {
+	return r == '-' || r == ' '
 }
 
 // String formats an address, returning it as a string.
 func (a *Address) String() string {
-	var address string
-	if a.StreetName == "PO Box" {
-		address = a.StreetName + " " + a.HouseNumber
-	} else {
-		if strings.ToUpper(a.State) == "WA" || strings.Index(a.Original, fmt.Sprintf(" %s", a.StreetDirection)) > strings.Index(a.Original, fmt.Sprintf(" %s", a.StreetType)) && a.StreetDirection != "" {
-			address = fmt.Sprintf("%v %s %s %s", a.HouseNumber, a.StreetName, a.StreetType, a.StreetDirection)
-		} else {
-			address = fmt.Sprintf("%v %s %s %s", a.HouseNumber, a.StreetDirection, a.StreetName, a.StreetType)
-		}
-		if a.Unit != "" {
-			address += " #" + a.Unit
-		}
-	}
-	address = strings.TrimSpace(address)
+	address := a.streetLine()
 
 	if a.City != "" {
 		address += " " + a.City
 	}
-	if a.State != "" {
+	if a.State != "" && a.Kind == KindMilitary {
+		// APO AE 09204 has no comma.
+		address += " " + a.State
+	} else if a.State != "" {
 		address += ", " + a.State
 	}
 	if a.PostalCode != "" {
 		address += " " + a.PostalCode
 	}
+	if a.PostalCode != "" && a.PostalCodeExt != "" {
+		address += "-" + a.PostalCodeExt
+	}
 
-	return strings.Replace(address, "  ", " ", -1)
+	return strings.TrimSpace(address)
 }
 
 // Street formats an address to string (street only).
 func (a *Address) Street() string {
-	var address string
 	if a == nil {
 		return ""
 	} else if !strings.EqualFold(a.Original, "") {
 		return strings.Split(a.Original, a.City)[0]
-	} else if a.StreetName == "PO Box" {
+	}
+
+	return a.streetLine()
+}
+
+// streetLine formats the street parts of an address.
+func (a *Address) streetLine() string {
+	var address string
+	if a.Kind == KindMilitary {
+		address = formatMilitary(a.MilitaryUnitType, a.MilitaryUnit, a.Box)
+	} else if a.Kind.isRoute() {
+		address = formatRoute(a.Kind, a.Route, a.Box)
+	} else if a.Kind == KindPoBox || strings.EqualFold(a.StreetName, "PO Box") {
 		address = a.StreetName + " " + a.HouseNumber
+	} else if a.GridCoordinate != "" {
+		address = fmt.Sprintf("%v %s %s %s %s", a.HouseNumber, StreetDirectionAbbr(a.PreDirectional), a.GridCoordinate, a.GridDirection, formatUnits(a.Units, a.UnitDesignator, a.Unit))
 	} else {
-		if strings.ToUpper(a.State) == "WA" || strings.Index(a.Original, fmt.Sprintf(" %s", a.StreetDirection)) > strings.Index(a.Original, fmt.Sprintf(" %s", a.StreetType)) && a.StreetDirection != "" {
-			address = fmt.Sprintf("%v %s %s %s", a.HouseNumber, a.StreetName, a.StreetType, a.StreetDirection)
-		} else {
-			address = fmt.Sprintf("%v %s %s %s", a.HouseNumber, a.StreetDirection, a.StreetName, a.StreetType)
-		}
-		if a.Unit != "" {
-			address += " #" + a.Unit
+		pre, post := a.PreDirectional, a.PostDirectional
+		if pre == "" && post == "" {
+			pre = a.StreetDirection
 		}
+
+		address = fmt.Sprintf("%v %s %s %s %s %s %s", a.HouseNumber, pre, a.StreetPreType, a.StreetName, a.StreetType, post, formatUnits(a.Units, a.UnitDesignator, a.Unit))
+	}
+
+	if a.Urbanization != "" {
+		// Puerto Rico addresses lead with their urbanization.
+		address = "URB " + a.Urbanization + " " + address
 	}
-	address = strings.TrimSpace(address)
 
-	return strings.Replace(address, "  ", " ", -1)
+	return strings.Join(strings.Fields(address), " ")
 }
 
-func isApartmentKeyword(s string) bool {
-	s = strings.ToUpper(strings.TrimSpace(s))
-	if s == "APT" || s == "#" || s == "UNIT" {
-		return true
+// looksLikeZipcode reports whether s is shaped like a zip code,
+// valid or not.
+func looksLikeZipcode(s string) bool {
+	s = strings.Split(s, "-")[0]
+
+	return isInt(s) && len(s) >= 3
+}
+
+// isStateLike reports whether s is shaped like a state abbreviation
+// but isn't a known state, street type or direction.
+func isStateLike(s string) bool {
+	if len(s) != 2 || IsState(s) || IsStreetType(s) || IsStreetDirection(s) {
+		return false
 	}
 
-	return false
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
 }
 
 func isInt(s string) bool {
@@ -261,7 +268,3 @@ func isInt(s string) bool {
 
 	return err == nil
 }
-
-func split(r rune) bool {
-	return r == ',' || r == ' '
-}
@@ -0,0 +1,89 @@
+package godress
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ParseCandidates parses an ambiguous address every reasonable way,
+// returning up to n readings ordered from most to least likely. Words like
+// PARK, FORK and SPRING can be a street name, street type or part of a city,
+// so each possible street type and street name ending is tried.
+//
+// When none of the readings are a usable address, the reading Parse would
+// return is given along with its error.
+func ParseCandidates(address string, n int) ([]*Result, error) {
+	return defaultParser.ParseCandidates(address, n)
+}
+
+// ParseCandidates parses an ambiguous address every reasonable way, like
+// the package level ParseCandidates.
+func (p *Parser) ParseCandidates(address string, n int) ([]*Result, error) {
+	l, a, err := p.parse(address)
+	greedy := newResult(l, a, err)
+
+	var results []*Result
+	if err == nil {
+		results = append(results, greedy)
+	}
+
+	states := []labelOptions{{}}
+	if l.ambiguousState {
+		states = []labelOptions{{forceState: true, state: true}, {forceState: true, state: false}}
+	}
+
+	for _, opts := range states {
+		base, _, _ := p.parseWith(address, opts)
+		for _, split := range streetSplits(base) {
+			split.forceState, split.state = opts.forceState, opts.state
+
+			l, a, err := p.parseWith(address, split)
+			if err != nil {
+				continue
+			}
+
+			results = appendCandidate(results, newResult(l, a, nil))
+		}
+	}
+
+	if len(results) == 0 {
+		return []*Result{greedy}, err
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if n >= 0 && len(results) > n {
+		results = results[:n]
+	}
+
+	return results, nil
+}
+
+// streetSplits lists the ways the street tokens of l could be divided into
+// a street name and type, up to the first unit keyword.
+func streetSplits(l *labeling) (splits []labelOptions) {
+	for i := l.nameStart + 1; i <= len(l.street); i++ {
+		if i < len(l.street) && l.isUnitAt(l.street, i, false) {
+			break
+		}
+
+		splits = append(splits, labelOptions{forceSplit: true, typeIdx: -1, nameEnd: i})
+		if i < len(l.street) && l.p.isStreetType(l.street[i].text) {
+			splits = append(splits, labelOptions{forceSplit: true, typeIdx: i})
+		}
+	}
+
+	return
+}
+
+func appendCandidate(results []*Result, r *Result) []*Result {
+	for _, existing := range results {
+		if reflect.DeepEqual(existing.Address, r.Address) {
+			return results
+		}
+	}
+
+	return append(results, r)
+}
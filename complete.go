@@ -0,0 +1,74 @@
+package godress
+
+// Complete fills in the city and state of an address from its zip code,
+// or its zip code from its city and state when only one zip code fits. The
+// fields it fills are added to Inferred, so they can be told apart from
+// the ones that were parsed. When more than one zip code fits, or the zip
+// code data is the embedded sample, which can't tell that only one does,
+// they are set as ZipSuggestions instead. It reports whether anything was
+// filled in.
+func Complete(a *Address) bool {
+	if a.PostalCode != "" {
+		info, ok := LookupZip(a.PostalCode)
+
+		return ok && completeFromZip(a, info)
+	}
+
+	if a.City == "" || a.State == "" {
+		return false
+	}
+
+	infos := ZipsForCity(a.City, a.State)
+	if len(infos) == 1 && !zipDataIsSample() {
+		a.PostalCode = infos[0].Zip
+		a.infer(FieldPostalCode)
+
+		return true
+	}
+
+	a.ZipSuggestions = nil
+	for _, info := range infos {
+		a.ZipSuggestions = append(a.ZipSuggestions, info.Zip)
+	}
+
+	return false
+}
+
+// completeFromZip fills in the city and state of an address from its zip
+// code's information, reporting whether either was missing.
+func completeFromZip(a *Address, info *ZipInfo) (filled bool) {
+	if a.City == "" && info.PrimaryCity != "" {
+		a.City = info.PrimaryCity
+		a.infer(FieldCity)
+		filled = true
+	}
+	if a.State == "" && info.State != "" {
+		a.State = info.State
+		a.infer(FieldState)
+		filled = true
+	}
+
+	return
+}
+
+// infer marks a field as inferred instead of parsed.
+func (a *Address) infer(field Field) {
+	for _, f := range a.Inferred {
+		if f == field {
+			return
+		}
+	}
+
+	a.Inferred = append(a.Inferred, field)
+}
+
+// IsInferred reports whether a field was inferred instead of parsed.
+func (a *Address) IsInferred(field Field) bool {
+	for _, f := range a.Inferred {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
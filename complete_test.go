@@ -0,0 +1,42 @@
+package godress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	p := NewParser(WithCompletion())
+
+	tests := map[string]*Address{
+		"123 Main St 84043":          {City: "LEHI", State: "UT", PostalCode: "84043", Inferred: []Field{FieldCity, FieldState}},
+		"123 Main St, Lehi UT":       {City: "LEHI", State: "UT", ZipSuggestions: []string{"84043"}},
+		"123 Main St, Lehi 84043":    {City: "LEHI", State: "UT", PostalCode: "84043", Inferred: []Field{FieldState}},
+		"123 Main St, Lehi UT 84043": {City: "LEHI", State: "UT", PostalCode: "84043"},
+		"123 Main St, Salt Lake City UT": {
+			City: "SALT LAKE CITY", State: "UT", ZipSuggestions: []string{"84101", "84104", "84111"},
+		},
+		"123 Main St 84401": {PostalCode: "84401"},
+	}
+
+	for s, expected := range tests {
+		a, err := p.Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := &Address{City: a.City, State: a.State, PostalCode: a.PostalCode, Inferred: a.Inferred, ZipSuggestions: a.ZipSuggestions}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected %+v, got %+v", s, expected, got)
+		}
+	}
+
+	if a, _ := Parse("123 Main St 84043"); a.City != "" || a.IsInferred(FieldCity) {
+		t.Errorf("expected completion to be opt in, got %q", a.City)
+	}
+
+	a, _ := NewParser(WithCompletion(), WithOriginalCase()).Parse("123 Main St 84043")
+	if a.City != "Lehi" || !a.IsInferred(FieldCity) {
+		t.Errorf("expected an inferred Lehi, got %q %v", a.City, a.Inferred)
+	}
+}
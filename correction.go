@@ -0,0 +1,269 @@
+package godress
+
+import "strings"
+
+// Correction is a misspelling fixed by a lenient Parser, i.e. Stret
+// corrected to STREET. From is the misspelled text as written in the
+// input, and To the word it was corrected to, in uppercase.
+type Correction struct {
+	Field Field  `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// misspelling is a correction of the tokens starting at start, one word
+// for each token.
+type misspelling struct {
+	start int
+	words []string
+	field Field
+}
+
+// correctTokens fixes the misspelled words of tokens, going by where they
+// fall when labeled, and returns the corrections made. Fixing one word
+// can change how the others are labeled, so they are labeled again until
+// nothing is left to fix.
+func (p *Parser) correctTokens(tokens []*token) (corrections []Correction) {
+	for pass := 0; pass < 3; pass++ {
+		l := p.label(cloneTokens(tokens), false, labelOptions{})
+
+		fixes := l.misspellings()
+		if len(fixes) == 0 {
+			break
+		}
+
+		for _, fix := range fixes {
+			from := make([]string, 0, len(fix.words))
+			for i, word := range fix.words {
+				t := tokens[fix.start+i]
+				from = append(from, t.raw)
+				t.text, t.raw = word, strings.Title(strings.ToLower(word))
+			}
+
+			corrections = append(corrections, Correction{
+				Field: fix.field,
+				From:  strings.Join(from, " "),
+				To:    strings.Join(fix.words, " "),
+			})
+		}
+	}
+
+	return corrections
+}
+
+// raw returns the tokens labeled lbl as written in the input.
+func (l *labeling) raw(lbl label) string {
+	var words []string
+	for _, t := range l.tokens {
+		if t.label == lbl {
+			words = append(words, t.raw)
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// correctCity fixes a misspelled city of an address, written raw in the
+// input, going by the cities of its zip code, or of its state without one,
+// in the zip code data. The embedded sample data leaves out most cities of
+// a state, so only the cities of a known zip code are gone by with it.
+func (p *Parser) correctCity(a *Address, raw string) {
+	city := strings.ToUpper(strings.Join(strings.Fields(a.City), " "))
+	if city == "" || len(ZipsForCity(city, a.State)) > 0 {
+		return
+	}
+
+	var names []string
+	if info, ok := LookupZip(a.PostalCode); ok {
+		names = append([]string{info.PrimaryCity}, info.AcceptableCities...)
+	} else if !zipDataIsSample() {
+		names = cityNames(StateAbbreviation(a.State))
+	}
+
+	dictionary := map[string]string{}
+	for _, name := range names {
+		if name == city {
+			return
+		}
+		dictionary[name] = name
+	}
+
+	to, ok := fuzzyMatch(city, dictionary)
+	if !ok {
+		return
+	}
+
+	a.Corrections = append(a.Corrections, Correction{Field: FieldCity, From: raw, To: to})
+	a.City = to
+	if p.originalCase {
+		a.City = strings.Title(strings.ToLower(to))
+	}
+}
+
+// misspellings finds the misspelled state, pre directional, street type
+// and units of a labeling.
+func (l *labeling) misspellings() (fixes []misspelling) {
+	if fix, ok := l.misspelledState(); ok {
+		fixes = append(fixes, fix)
+	}
+
+	name := -1
+	has := map[label]bool{}
+	for i, t := range l.tokens {
+		if name < 0 && t.label == labelStreetName {
+			name = i
+		}
+		has[t.label] = true
+	}
+	if l.kind != KindStreet || name < 0 {
+		return fixes
+	}
+
+	streetOrCity := func(i int) bool {
+		return i < len(l.tokens) && (l.tokens[i].label == labelStreetName || l.tokens[i].label == labelCity)
+	}
+	fixed := map[int]bool{}
+	fix := func(i int, field Field, dictionary map[string]string) bool {
+		if fixed[i] || !l.misspelled(l.tokens[i].text) {
+			return false
+		}
+
+		word, ok := fuzzyMatch(l.tokens[i].text, dictionary)
+		if ok {
+			fixes = append(fixes, misspelling{start: i, words: []string{word}, field: field})
+			fixed[i] = true
+		}
+
+		return ok
+	}
+
+	// A direction is only looked for before a street name, after it one
+	// is too easily taken for the city.
+	if !has[labelPreDirectional] && l.preType == nil && !l.grid && name+1 < len(l.tokens) && l.tokens[name+1].label == labelStreetName {
+		fix(name, FieldPreDirectional, directionDictionary())
+	}
+
+	for i := name + 1; i+1 < len(l.tokens); i++ {
+		if streetOrCity(i) && streetOrCity(i+1) && hasDigit(l.tokens[i+1].text) {
+			fix(i, FieldUnitDesignator, l.p.unitDictionary())
+		}
+	}
+
+	// The street type has to leave something for the city, so Three
+	// Rivers, MI isn't taken for Three River.
+	if !has[labelStreetType] && l.preType == nil && !l.grid {
+		types := l.p.streetTypeDictionary()
+		for i := name + 1; streetOrCity(i); i++ {
+			if (l.tokens[i].comma || streetOrCity(i+1)) && fix(i, FieldStreetType, types) {
+				break
+			}
+		}
+	}
+
+	return fixes
+}
+
+// misspelledState finds a misspelled state name just before the zip code,
+// or at the end of an address without one.
+func (l *labeling) misspelledState() (misspelling, bool) {
+	end := len(l.tokens)
+	for end > 0 && l.tokens[end-1].label == labelPostalCode {
+		end--
+	}
+	for _, t := range l.tokens[:end] {
+		if t.label == labelState {
+			return misspelling{}, false
+		}
+	}
+
+	for n := 2; n > 0; n-- {
+		start := end - n
+		if start < 1 {
+			continue
+		}
+
+		words := make([]string, 0, n)
+		for i, t := range l.tokens[start:end] {
+			if t.label != labelCity || !l.misspelled(t.text) || (t.comma && i < n-1) {
+				break
+			}
+			words = append(words, t.text)
+		}
+		if len(words) < n {
+			continue
+		}
+
+		if name, ok := fuzzyMatch(strings.Join(words, " "), stateDictionary(n)); ok {
+			return misspelling{start: start, words: strings.Fields(name), field: FieldState}, true
+		}
+	}
+
+	return misspelling{}, false
+}
+
+// misspelled reports whether s could be a misspelled word, that is it has
+// no digits and isn't already a word of the dictionaries.
+func (l *labeling) misspelled(s string) bool {
+	return !hasDigit(s) && !l.p.isStreetType(s) && !IsStreetDirection(s) && !IsState(s) && l.p.unitDesignators[s] == nil
+}
+
+// streetTypeDictionary maps the spellings of the parser's street types to
+// their primary names.
+func (p *Parser) streetTypeDictionary() map[string]string {
+	dictionary := map[string]string{}
+	for alias, suffix := range p.streetTypes {
+		dictionary[alias] = suffix.primary
+	}
+
+	return dictionary
+}
+
+// unitDictionary maps the parser's unit designators to their labels.
+func (p *Parser) unitDictionary() map[string]string {
+	dictionary := map[string]string{}
+	for alias, term := range p.unitDesignators {
+		if alias != "#" {
+			dictionary[alias] = strings.ToUpper(term.Label)
+		}
+	}
+
+	return dictionary
+}
+
+// directionDictionary maps the spelled out directions to themselves.
+func directionDictionary() map[string]string {
+	dictionary := map[string]string{}
+	for direction := range streetDirections {
+		if len(direction) > 2 {
+			dictionary[direction] = direction
+		}
+	}
+
+	return dictionary
+}
+
+// stateDictionary maps the names of the states that are n words long to
+// themselves, uppercased.
+func stateDictionary(n int) map[string]string {
+	dictionary := map[string]string{}
+	for _, state := range stateList {
+		if name := strings.ToUpper(state.Name); len(strings.Fields(name)) == n {
+			dictionary[name] = name
+		}
+	}
+
+	return dictionary
+}
+
+func cloneTokens(tokens []*token) []*token {
+	clones := make([]*token, len(tokens))
+	for i, t := range tokens {
+		clones[i] = &token{raw: t.raw, text: t.text, comma: t.comma}
+	}
+
+	return clones
+}
+
+func hasDigit(s string) bool {
+	return strings.ContainsAny(s, "0123456789")
+}
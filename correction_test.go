@@ -0,0 +1,86 @@
+package godress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	p := NewParser(WithLenient())
+
+	tests := map[string][]Correction{
+		"123 Main Stret, Lehhi, Utha 84043": {
+			{FieldState, "Utha", "UTAH"},
+			{FieldStreetType, "Stret", "STREET"},
+			{FieldCity, "Lehhi", "LEHI"},
+		},
+		"123 Main Avenu Lehi Calfornia 84043": {{FieldState, "Calfornia", "CALIFORNIA"}},
+		"123 Nort Main St Suit 5 Lehi UT 84043": {
+			{FieldPreDirectional, "Nort", "NORTH"},
+			{FieldUnitDesignator, "Suit", "SUITE"},
+		},
+		"123 Main St Salt Lak City UT 84101": {{FieldCity, "Salt Lak City", "SALT LAKE CITY"}},
+		"123 Main St, Lehi, New Jresey":      {{FieldState, "New Jresey", "NEW JERSEY"}},
+		"123 N Center St Lehi, UT 84043":     nil,
+		"123 Weston Ave Lehi UT":             nil,
+		"123 Main Three Rivers MI":           nil,
+		"137 N 800 E Spanish Fork, UT 84660": nil,
+	}
+
+	for s, expected := range tests {
+		a, err := p.Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(a.Corrections, expected) {
+			t.Errorf("%s: expected corrections %+v, got %+v", s, expected, a.Corrections)
+		}
+	}
+
+	a, _ := p.Parse("123 Main Stret, Lehhi, Utha 84043")
+	if a.StreetName != "MAIN" || a.StreetType != "STREET" || a.City != "LEHI" || a.State != "UT" {
+		t.Errorf("expected the corrected address, got %s", a)
+	}
+
+	a, _ = NewParser(WithLenient(), WithOriginalCase()).Parse("123 Main St, Lehhi, UT 84043")
+	if a.City != "Lehi" || !reflect.DeepEqual(a.Corrections, []Correction{{FieldCity, "Lehhi", "LEHI"}}) {
+		t.Errorf("expected Lehhi corrected to Lehi, got %q %+v", a.City, a.Corrections)
+	}
+
+	if a, _ := Parse("123 Main Stret, Lehhi, Utha 84043"); a.Corrections != nil || a.City == "LEHI" {
+		t.Errorf("expected correction to be opt in, got %+v", a.Corrections)
+	}
+
+	r, _ := p.ParseWithConfidence("123 Main St, Lehhi, UT 84043")
+	good, _ := p.ParseWithConfidence("123 Main St, Lehi, UT 84043")
+	if r.Confidence[FieldCity] >= good.Confidence[FieldCity] {
+		t.Errorf("expected a corrected city to be less certain, got %v", r.Confidence[FieldCity])
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	dictionary := map[string]string{"STREET": "STREET", "STR": "STREET", "WEST": "WEST", "UTAH": "UTAH"}
+
+	tests := map[string]string{
+		"STRET":   "STREET",
+		"STREEET": "STREET",
+		"UTHA":    "UTAH",
+		"WSET":    "WEST",
+		"WESTON":  "",
+		"STA":     "",
+		"XTREET":  "",
+	}
+
+	for s, expected := range tests {
+		if got, _ := fuzzyMatch(s, dictionary); got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	for s, expected := range map[string]string{"ROBERT": "R163", "RUPERT": "R163", "ASHCRAFT": "A261", "TYMCZAK": "T522", "LEE": "L000"} {
+		if got := soundex(s); got != expected {
+			t.Errorf("soundex(%s): expected %s, got %s", s, expected, got)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package godress
+
+import "strings"
+
+// DuplicateScore is the lowest match score at which two addresses of the
+// same building are taken for duplicates by Dedupe.
+const DuplicateScore = 0.9
+
+// Cluster is a group of addresses that are the same place, with the one
+// picked to stand for them all.
+type Cluster struct {
+	Representative *Address        `json:"representative"`
+	Members        []ClusterMember `json:"members"`
+}
+
+// ClusterMember is one of the addresses of a cluster. Index is where it
+// was in the list given to Dedupe, and Match how it compares to the
+// representative, which tells why it was merged.
+type ClusterMember struct {
+	Index          int         `json:"index"`
+	Input          string      `json:"input"`
+	Address        *Address    `json:"address"`
+	Representative bool        `json:"representative,omitempty"`
+	Match          MatchResult `json:"match"`
+	Err            error       `json:"-"`
+}
+
+// Dedupe parses a list of addresses and groups the ones that are the same
+// place into clusters, in the order their first address appears. An
+// address that fails to parse is left in a cluster of its own.
+func Dedupe(addresses []string) []*Cluster {
+	return defaultParser.Dedupe(addresses)
+}
+
+// Dedupe parses a list of addresses with the parser and groups them like
+// the package level Dedupe.
+func (p *Parser) Dedupe(addresses []string) []*Cluster {
+	members := make([]ClusterMember, len(addresses))
+	for i, s := range addresses {
+		a, err := p.Parse(s)
+		members[i] = ClusterMember{Index: i, Input: s, Address: a, Err: err}
+	}
+
+	return cluster(members)
+}
+
+// DedupeAddresses groups addresses that were already parsed like Dedupe.
+// A nil address is left in a cluster of its own.
+func DedupeAddresses(addresses []*Address) []*Cluster {
+	members := make([]ClusterMember, len(addresses))
+	for i, a := range addresses {
+		members[i] = ClusterMember{Index: i, Address: a}
+		if a != nil {
+			members[i].Input = a.Original
+		}
+	}
+
+	return cluster(members)
+}
+
+// cluster groups members that are duplicates of each other. Only members
+// that share a blocking key are compared, which keeps a long list from
+// being compared pair by pair.
+func cluster(members []ClusterMember) []*Cluster {
+	blocks := map[string][]int{}
+	var keys []string
+	for i, m := range members {
+		if m.Err != nil || m.Address == nil {
+			continue
+		}
+
+		for _, key := range blockingKeys(m.Address) {
+			if _, ok := blocks[key]; !ok {
+				keys = append(keys, key)
+			}
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	parent := make([]int, len(members))
+	group := make([][]int, len(members))
+	for i := range parent {
+		parent[i] = i
+		group[i] = []int{i}
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	// Two groups are only merged when none of their units tell them
+	// apart, so APT 4 and APT 5 aren't merged through an address
+	// without a unit.
+	units := func(i, j int) bool {
+		for _, x := range group[find(i)] {
+			for _, y := range group[find(j)] {
+				ux, uy := canonicalUnits(members[x].Address), canonicalUnits(members[y].Address)
+				if ux != "" && uy != "" && ux != uy {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	compared := map[[2]int]bool{}
+	for _, key := range keys {
+		block := blocks[key]
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				i, j := block[x], block[y]
+				if compared[[2]int{i, j}] || find(i) == find(j) {
+					continue
+				}
+				compared[[2]int{i, j}] = true
+
+				if isDuplicate(Match(members[i].Address, members[j].Address)) && units(i, j) {
+					ri, rj := find(i), find(j)
+					parent[rj] = ri
+					group[ri], group[rj] = append(group[ri], group[rj]...), nil
+				}
+			}
+		}
+	}
+
+	groups := map[int]*Cluster{}
+	var clusters []*Cluster
+	for i, m := range members {
+		root := find(i)
+		c, ok := groups[root]
+		if !ok {
+			c = &Cluster{}
+			groups[root] = c
+			clusters = append(clusters, c)
+		}
+		c.Members = append(c.Members, m)
+	}
+
+	for _, c := range clusters {
+		c.pickRepresentative()
+	}
+
+	return clusters
+}
+
+// isDuplicate reports whether a match is close enough for the addresses to
+// be merged: exact, or the same building by a high enough score, like one
+// with a typo or without the unit of the other.
+func isDuplicate(r MatchResult) bool {
+	return r.Level == MatchExact || (r.Level == MatchBuilding && r.Score >= DuplicateScore)
+}
+
+// pickRepresentative picks the most complete address of the cluster, the
+// first one of them on a tie, and matches every member against it.
+func (c *Cluster) pickRepresentative() {
+	best := 0
+	for i, m := range c.Members {
+		if m.Address != nil && completeness(m.Address) > completeness(c.Members[best].Address) {
+			best = i
+		}
+	}
+
+	c.Members[best].Representative = true
+	c.Representative = c.Members[best].Address
+	for i := range c.Members {
+		if c.Representative != nil && c.Members[i].Address != nil {
+			c.Members[i].Match = Match(c.Members[i].Address, c.Representative)
+		}
+	}
+}
+
+// completeness counts the fields of an address that are filled in.
+func completeness(a *Address) int {
+	if a == nil {
+		return -1
+	}
+
+	n := 0
+	for _, field := range []string{
+		a.Urbanization, a.HouseNumber, a.PreDirectional, a.StreetPreType, a.StreetName, a.StreetType,
+		a.PostDirectional, a.Unit, a.Route, a.Box, a.MilitaryUnit, a.City, a.State, a.PostalCode,
+		a.PostalCodeExt,
+	} {
+		if field != "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// blockingKeys returns the keys of the blocks an address is compared in:
+// its zip code, or city and state, with the sound of its street name and
+// its house number, or its box for addresses without a street.
+func blockingKeys(a *Address) []string {
+	var street string
+	switch {
+	case a.Kind == KindMilitary:
+		street = canonical(a.MilitaryUnitType, a.MilitaryUnit, a.Box)
+	case a.Kind.isRoute():
+		street = canonical(a.Route, a.Box)
+	case a.Kind == KindPoBox:
+		street = canonical("BOX", a.Box)
+	default:
+		street = canonical(phoneticKey(a.StreetName), a.HouseNumber)
+	}
+
+	var keys []string
+	if zip, _, _ := splitZipcode(a.PostalCode); zip != "" {
+		keys = append(keys, zip+"|"+street)
+	}
+	if city, state := canonical(a.City), StateAbbreviation(a.State); city != "" && state != "" {
+		keys = append(keys, city+"|"+state+"|"+street)
+	}
+	if len(keys) == 0 {
+		keys = append(keys, "|"+street)
+	}
+
+	return keys
+}
+
+// phoneticKey returns the soundex code of each word of a street name,
+// keeping the words that aren't spelled with letters, like 135TH, as is.
+func phoneticKey(name string) string {
+	words := strings.Fields(canonical(name))
+	for i, word := range words {
+		if code := soundex(word); code != "" {
+			words[i] = code
+		}
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,72 @@
+package godress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupe(t *testing.T) {
+	addresses := []string{
+		"123 North Center Street, Lehi, UT 84043",
+		"125 N Center St, Lehi, UT 84043",
+		"123 N Center St Lehi UT 84043-1234",
+		"123 N Centre St, Lehi, UT 84043",
+		"123 Main St Apt 4, Lehi, UT 84043",
+		"123 Main St Apt 5, Lehi, UT 84043",
+		"123 Main St, Lehi, UT 84043",
+		"PO Box 12, Lehi, UT 84043",
+		"P.O. Box 12 Lehi UT",
+		"123",
+	}
+
+	clusters := Dedupe(addresses)
+
+	var got [][]int
+	for _, c := range clusters {
+		var indexes []int
+		for _, m := range c.Members {
+			indexes = append(indexes, m.Index)
+		}
+		got = append(got, indexes)
+	}
+	expected := [][]int{{0, 2, 3}, {1}, {4, 6}, {5}, {7, 8}, {9}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected clusters %v, got %v", expected, got)
+	}
+
+	c := clusters[0]
+	if c.Representative != c.Members[1].Address || !c.Members[1].Representative {
+		t.Errorf("expected the ZIP+4 address to represent the cluster, got %s", c.Representative)
+	}
+	if m := c.Members[2]; m.Match.Level != MatchBuilding || m.Input != addresses[3] {
+		t.Errorf("expected the typo to be merged as the same building, got %s", m.Match.Level)
+	}
+	if m := clusters[5].Members[0]; m.Err == nil || clusters[5].Representative != m.Address {
+		t.Errorf("expected an address that fails to parse in a cluster of its own, got %+v", m)
+	}
+
+	parsed := []*Address{MustParse("123 N Center St Lehi UT 84043"), MustParse("123 North Center Street Lehi UT 84043")}
+	if clusters := DedupeAddresses(parsed); len(clusters) != 1 || len(clusters[0].Members) != 2 {
+		t.Errorf("expected parsed addresses to be deduplicated, got %d clusters", len(clusters))
+	}
+
+	clusters = DedupeAddresses([]*Address{MustParse("123 Main St"), nil, MustParse("123 Main St")})
+	if len(clusters) != 2 || len(clusters[0].Members) != 2 || clusters[1].Members[0].Index != 1 || clusters[1].Representative != nil {
+		t.Errorf("expected a nil address in a cluster of its own, got %d clusters", len(clusters))
+	}
+}
+
+func TestBlockingKeys(t *testing.T) {
+	tests := map[string][]string{
+		"123 N Center St, Lehi, UT 84043": {"84043|C536 123", "LEHI|UT|C536 123"},
+		"2505 NE 135th St 98125":          {"98125|135TH 2505"},
+		"PO Box 12, Lehi, UT":             {"LEHI|UT|BOX 12"},
+		"123 Main St":                     {"|M500 123"},
+	}
+
+	for s, expected := range tests {
+		if got := blockingKeys(MustParse(s)); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package godress
+
+import "fmt"
+
+// ParseErrorKind identifies why an address could not be parsed.
+type ParseErrorKind int
+
+const (
+	// MissingHouseNumber means no house (or box) number was found.
+	MissingHouseNumber ParseErrorKind = iota + 1
+	// MissingStreetName means no street name was found.
+	MissingStreetName
+	// UnknownState means the token in the state position is not a known state.
+	UnknownState
+	// InvalidZip means the token in the zip code position is not a valid zip code.
+	InvalidZip
+	// MissingCity means no city was found by a strict parser.
+	MissingCity
+	// MissingState means no state was found by a strict parser.
+	MissingState
+	// MissingZip means no zip code was found by a strict parser.
+	MissingZip
+)
+
+var parseErrorKindNames = map[ParseErrorKind]string{
+	MissingHouseNumber: "missing house number",
+	MissingStreetName:  "missing street name",
+	UnknownState:       "unknown state",
+	InvalidZip:         "invalid zip code",
+	MissingCity:        "missing city",
+	MissingState:       "missing state",
+	MissingZip:         "missing zip code",
+}
+
+// String returns a human readable description of the kind.
+func (k ParseErrorKind) String() string {
+	if name, ok := parseErrorKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("ParseErrorKind(%d)", int(k))
+}
+
+// ParseError is returned when an address, or part of one, could not be parsed.
+type ParseError struct {
+	Kind  ParseErrorKind
+	Token string
+	Input string
+}
+
+func newParseError(kind ParseErrorKind, token, input string) *ParseError {
+	return &ParseError{Kind: kind, Token: token, Input: input}
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("godress: %s in %q", e.Kind, e.Input)
+	}
+
+	return fmt.Sprintf("godress: %s %q in %q", e.Kind, e.Token, e.Input)
+}
@@ -0,0 +1,375 @@
+package godress
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Formatter formats an address as text.
+type Formatter interface {
+	Format(a *Address) string
+}
+
+// FormatterFunc is a function that formats an address, as a Formatter.
+type FormatterFunc func(a *Address) string
+
+// Format formats an address by calling f.
+func (f FormatterFunc) Format(a *Address) string {
+	return f(a)
+}
+
+// Layout is which parts of an address a Style writes, and on which lines.
+type Layout int
+
+const (
+	// LayoutSingleLine writes the whole address on one line, i.e.
+	// 123 N MAIN ST APT 4, LEHI, UT 84043.
+	LayoutSingleLine Layout = iota
+	// LayoutMultiLine writes the street and the city, state and zip code
+	// on lines of their own, like on a shipping label.
+	LayoutMultiLine
+	// LayoutStreet writes the street line only, i.e. 123 N MAIN ST APT 4.
+	LayoutStreet
+	// LayoutCityStateZip writes the city, state and zip code only, i.e.
+	// LEHI, UT 84043.
+	LayoutCityStateZip
+)
+
+var layoutNames = map[Layout]string{
+	LayoutSingleLine:   "single line",
+	LayoutMultiLine:    "multi-line",
+	LayoutStreet:       "street",
+	LayoutCityStateZip: "city, state and zip code",
+}
+
+// String returns a human readable description of the layout.
+func (l Layout) String() string {
+	if name, ok := layoutNames[l]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Layout(%d)", int(l))
+}
+
+// Style is a Formatter that writes an address in one of the layouts, from
+// its standardized form. Expanded spells out the directions, street types,
+// unit designators and states instead of abbreviating them, and TitleCase
+// writes words in title case instead of uppercase, keeping abbreviations
+// like NE and UT uppercase.
+type Style struct {
+	Layout    Layout
+	Expanded  bool
+	TitleCase bool
+}
+
+// Formatters for the common styles, in uppercase and abbreviated.
+var (
+	// FormatUSPS formats an address the way USPS Publication 28 writes it
+	// on mail, its urbanization, if any, delivery line and last line each
+	// on a line of their own:
+	//
+	//	123 N MAIN ST APT 4
+	//	LEHI UT 84043-1234
+	FormatUSPS Formatter = FormatterFunc(formatUSPS)
+
+	FormatSingleLine   Formatter = Style{Layout: LayoutSingleLine}
+	FormatLabel        Formatter = Style{Layout: LayoutMultiLine}
+	FormatStreet       Formatter = Style{Layout: LayoutStreet}
+	FormatCityStateZip Formatter = Style{Layout: LayoutCityStateZip}
+)
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]Formatter{
+		"usps":           FormatUSPS,
+		"single-line":    FormatSingleLine,
+		"label":          FormatLabel,
+		"street":         FormatStreet,
+		"city-state-zip": FormatCityStateZip,
+		"expanded":       Style{Layout: LayoutSingleLine, Expanded: true},
+		"title":          Style{Layout: LayoutSingleLine, TitleCase: true},
+		"label-title":    Style{Layout: LayoutMultiLine, TitleCase: true},
+	}
+)
+
+// RegisterFormat names a formatter, so it can be looked up by LookupFormat,
+// i.e. from configuration. It replaces any formatter of the same name.
+func RegisterFormat(name string, f Formatter) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats[name] = f
+}
+
+// LookupFormat finds a formatter by name, one of the built in usps,
+// single-line, label, street, city-state-zip, expanded, title and
+// label-title or one added by RegisterFormat.
+func LookupFormat(name string) (Formatter, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	f, ok := formats[name]
+
+	return f, ok
+}
+
+// FormatNames returns the names of the formatters, sorted.
+func FormatNames() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Format formats the address with f.
+func (a *Address) Format(f Formatter) string {
+	return f.Format(a)
+}
+
+// Format formats an address in the style.
+func (s Style) Format(a *Address) string {
+	std := a.Standardize()
+
+	var urbanization string
+	if std.Urbanization != "" {
+		urbanization = s.title("URB " + std.Urbanization)
+	}
+	street, city, stateZip := s.street(std), s.city(std), s.stateZip(std)
+
+	// Military addresses go without a comma, i.e. APO AE 09204.
+	last := strings.TrimSpace(city + " " + stateZip)
+	if std.Kind != KindMilitary && city != "" && stateZip != "" {
+		last = city + ", " + stateZip
+	}
+
+	var lines []string
+	switch s.Layout {
+	case LayoutMultiLine:
+		lines = []string{urbanization, street, last}
+	case LayoutStreet:
+		lines = []string{urbanization + " " + street}
+	case LayoutCityStateZip:
+		lines = []string{last}
+	default:
+		var parts []string
+		for _, part := range []string{urbanization, street, last} {
+			if part != "" {
+				parts = append(parts, part)
+			}
+		}
+		lines = []string{strings.Join(parts, ", ")}
+	}
+
+	return tidyLines(strings.Join(lines, "\n"))
+}
+
+// street writes the street line of a standardized address.
+func (s Style) street(a *Address) string {
+	var parts []string
+	switch {
+	case a.Kind == KindMilitary:
+		// Military addresses are always written in uppercase.
+		parts = []string{formatMilitary(a.MilitaryUnitType, a.MilitaryUnit, a.Box)}
+	case a.Kind.isRoute():
+		route := routeAbbreviations[a.Kind]
+		if s.Expanded {
+			route = s.title(routeNames[a.Kind])
+		}
+		parts = []string{route, a.Route}
+		if a.Box != "" {
+			parts = append(parts, s.title("BOX"), a.Box)
+		}
+	case a.Kind == KindPoBox || a.StreetName == "PO BOX":
+		box := a.Box
+		if box == "" {
+			box = a.HouseNumber
+		}
+		parts = []string{"PO", s.title("BOX"), box}
+	case a.GridCoordinate != "":
+		parts = []string{a.HouseNumber, s.direction(a.PreDirectional), a.GridCoordinate, s.direction(a.GridDirection)}
+	default:
+		streetType := a.StreetType
+		if s.Expanded && streetType != "" {
+			streetType = strings.ToUpper(StreetTypeName(streetType))
+		}
+		parts = []string{
+			a.HouseNumber,
+			s.direction(a.PreDirectional),
+			s.title(a.StreetPreType),
+			s.title(a.StreetName),
+			s.title(streetType),
+			s.direction(a.PostDirectional),
+		}
+	}
+
+	if a.Kind != KindMilitary {
+		for _, u := range a.Units {
+			designator := u.Designator
+			if term, ok := unitTerms[designator]; ok && s.Expanded && designator != "#" {
+				designator = strings.ToUpper(term.Label)
+			}
+			parts = append(parts, s.title(designator), u.Value)
+		}
+	}
+
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
+
+// city writes the city of a standardized address. Military post offices,
+// like APO, are always written in uppercase.
+func (s Style) city(a *Address) string {
+	if a.Kind == KindMilitary {
+		return a.City
+	}
+
+	return s.title(a.City)
+}
+
+// stateZip writes the state and ZIP+4 of a standardized address.
+func (s Style) stateZip(a *Address) string {
+	state := a.State
+	if st, ok := LookupState(state); ok && s.Expanded && a.Kind != KindMilitary {
+		state = s.title(strings.ToUpper(st.Name))
+	}
+
+	zip := a.PostalCode
+	if zip != "" && a.PostalCodeExt != "" {
+		zip += "-" + a.PostalCodeExt
+	}
+
+	return strings.TrimSpace(state + " " + zip)
+}
+
+// direction writes a direction, spelled out when the style is expanded.
+func (s Style) direction(d string) string {
+	if name, ok := directionNames[d]; ok && s.Expanded {
+		return s.title(name)
+	}
+
+	return d
+}
+
+// title writes s in title case when the style calls for it.
+func (s Style) title(v string) string {
+	if s.TitleCase {
+		return strings.Title(strings.ToLower(v))
+	}
+
+	return v
+}
+
+func formatUSPS(a *Address) string {
+	var lines []string
+	if urbanization := uspsText(a.Urbanization); urbanization != "" {
+		lines = append(lines, "URB "+urbanization)
+	}
+	lines = append(lines, a.DeliveryLine(), a.LastLine())
+
+	return tidyLines(strings.Join(lines, "\n"))
+}
+
+// TemplateFormatter is a Formatter made from a text/template executed on
+// the Address, so templates can use its fields and methods, like
+// {{.HouseNumber}} or {{.DeliveryLine}}. Templates can also call upper,
+// lower and title, and abbrDirection, abbrStreetType, abbrUnit and
+// abbrState, or their expand counterparts, to write a part in the form
+// they want. Runs of spaces left by blank fields are collapsed, and blank
+// lines dropped.
+type TemplateFormatter struct {
+	template *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"upper":         strings.ToUpper,
+	"lower":         strings.ToLower,
+	"title":         func(s string) string { return strings.Title(strings.ToLower(s)) },
+	"abbrDirection": StreetDirectionAbbr,
+	"expandDirection": func(s string) string {
+		if name, ok := directionNames[StreetDirectionAbbr(s)]; ok {
+			return name
+		}
+		return s
+	},
+	"abbrStreetType":   func(s string) string { return strings.ToUpper(StreetTypeAbbr(s)) },
+	"expandStreetType": func(s string) string { return strings.ToUpper(StreetTypeName(s)) },
+	"abbrUnit":         unitAbbr,
+	"expandUnit": func(s string) string {
+		if term, ok := unitTerms[strings.ToUpper(s)]; ok && term.Abbreviation != "#" {
+			return strings.ToUpper(term.Label)
+		}
+		return s
+	},
+	"abbrState": StateAbbreviation,
+	"expandState": func(s string) string {
+		if st, ok := LookupState(s); ok {
+			return strings.ToUpper(st.Name)
+		}
+		return s
+	},
+}
+
+// ParseTemplate parses a text/template into a TemplateFormatter, i.e.
+//
+//	{{.HouseNumber}} {{.StreetName}} {{title .City}}
+func ParseTemplate(text string) (*TemplateFormatter, error) {
+	t, err := template.New("address").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateFormatter{template: t}, nil
+}
+
+// MustParseTemplate parses a template like ParseTemplate, panicking when
+// it doesn't parse. It is meant for templates known at compile time.
+func MustParseTemplate(text string) *TemplateFormatter {
+	t, err := ParseTemplate(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// Execute formats an address with the template, returning the error the
+// template ran into, if any.
+func (t *TemplateFormatter) Execute(a *Address) (string, error) {
+	var buf bytes.Buffer
+	if err := t.template.Execute(&buf, a); err != nil {
+		return "", err
+	}
+
+	return tidyLines(buf.String()), nil
+}
+
+// Format formats an address with the template, returning an empty string
+// when the template fails.
+func (t *TemplateFormatter) Format(a *Address) string {
+	s, _ := t.Execute(a)
+
+	return s
+}
+
+// tidyLines collapses the runs of spaces in each line of s, trimming the
+// commas left dangling by blank fields, and drops blank lines.
+func tidyLines(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Trim(strings.Join(strings.Fields(line), " "), " ,")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,102 @@
+package godress
+
+import "testing"
+
+func TestFormatStyles(t *testing.T) {
+	tests := map[string]map[string]string{
+		"123 north main street apartment 4, Lehi, Utah 84043-1234": {
+			"single-line":    "123 N MAIN ST APT 4, LEHI, UT 84043-1234",
+			"label":          "123 N MAIN ST APT 4\nLEHI, UT 84043-1234",
+			"street":         "123 N MAIN ST APT 4",
+			"city-state-zip": "LEHI, UT 84043-1234",
+			"expanded":       "123 NORTH MAIN STREET APARTMENT 4, LEHI, UTAH 84043-1234",
+			"title":          "123 N Main St Apt 4, Lehi, UT 84043-1234",
+			"label-title":    "123 N Main St Apt 4\nLehi, UT 84043-1234",
+		},
+		"Rural Route 2 Box 15, Lehi, UT 84043": {
+			"single-line": "RR 2 BOX 15, LEHI, UT 84043",
+			"expanded":    "RURAL ROUTE 2 BOX 15, LEHI, UTAH 84043",
+			"title":       "RR 2 Box 15, Lehi, UT 84043",
+		},
+		"PSC 1234, Box 5678, APO, AE 09204": {
+			"label": "PSC 1234 BOX 5678\nAPO AE 09204",
+			"title": "PSC 1234 BOX 5678, APO AE 09204",
+		},
+		"URB Las Gladiolas 150 Calle A, San Juan, PR 00926": {
+			"single-line": "URB LAS GLADIOLAS, 150 CALLE A, SAN JUAN, PR 00926",
+			"label-title": "Urb Las Gladiolas\n150 Calle A\nSan Juan, PR 00926",
+		},
+		"P.O. Box 12, Provo, UT": {
+			"single-line":    "PO BOX 12, PROVO, UT",
+			"city-state-zip": "PROVO, UT",
+		},
+	}
+
+	for s, styles := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+
+		for name, expected := range styles {
+			f, ok := LookupFormat(name)
+			if !ok {
+				t.Fatalf("expected a %s format", name)
+			}
+			if got := a.Format(f); got != expected {
+				t.Errorf("%s: %s: expected %q, got %q", s, name, expected, got)
+			}
+		}
+	}
+}
+
+func TestFormatTemplate(t *testing.T) {
+	a, err := Parse("123 N Main St Apt 4, Lehi, UT 84043")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"{{.HouseNumber}} {{.StreetName}}":                                     "123 MAIN",
+		"{{.DeliveryLine}}\n{{.LastLine}}":                                     "123 N MAIN ST APT 4\nLEHI UT 84043",
+		"{{expandDirection .PreDirectional}} {{title .StreetName}}":            "NORTH Main",
+		"{{title .City}}, {{expandState .State}}":                              "Lehi, UTAH",
+		"{{.HouseNumber}} {{.PostDirectional}} {{.StreetName}}\n\n{{.County}}": "123 MAIN",
+		"{{.Urbanization}}, {{.City}}, {{.State}}":                             "LEHI, UT",
+		"{{expandUnit .UnitDesignator}} {{.Unit}}":                             "APARTMENT 4",
+	}
+
+	for text, expected := range tests {
+		f, err := ParseTemplate(text)
+		if err != nil {
+			t.Errorf("%q: %v", text, err)
+			continue
+		}
+		if got := a.Format(f); got != expected {
+			t.Errorf("%q: expected %q, got %q", text, expected, got)
+		}
+	}
+
+	if _, err := ParseTemplate("{{.HouseNumber"); err == nil {
+		t.Error("expected an error for an unclosed action")
+	}
+
+	f := MustParseTemplate("{{.NoSuchField}}")
+	if _, err := f.Execute(a); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+	if got := f.Format(a); got != "" {
+		t.Errorf("expected a failed template to format as empty, got %q", got)
+	}
+
+	RegisterFormat("csv", MustParseTemplate(`{{.HouseNumber}},{{.StreetName}},{{.City}}`))
+	defer func() {
+		formatsMu.Lock()
+		delete(formats, "csv")
+		formatsMu.Unlock()
+	}()
+	if f, ok := LookupFormat("csv"); !ok || a.Format(f) != "123,MAIN,LEHI" {
+		t.Errorf("expected the registered csv format, got %v", ok)
+	}
+}
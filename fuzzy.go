@@ -0,0 +1,139 @@
+package godress
+
+// editDistance returns the Damerau-Levenshtein distance between a and b,
+// the number of insertions, deletions, substitutions and swaps of adjacent
+// letters it takes to turn one into the other.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = d[i-1][j-1] + cost
+			if d[i-1][j]+1 < d[i][j] {
+				d[i][j] = d[i-1][j] + 1
+			}
+			if d[i][j-1]+1 < d[i][j] {
+				d[i][j] = d[i][j-1] + 1
+			}
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+// soundexCodes are the soundex digits of the consonants, vowels and H, W
+// and Y have none.
+var soundexCodes = map[rune]byte{
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+// soundex returns the American soundex code of an uppercase word, its
+// first letter followed by 3 digits for how the rest of it sounds.
+func soundex(s string) string {
+	var code []byte
+	var last byte
+	for i, r := range s {
+		digit := soundexCodes[r]
+		if i == 0 {
+			if r < 'A' || r > 'Z' {
+				return ""
+			}
+			code = append(code, byte(r))
+			last = digit
+			continue
+		}
+
+		switch {
+		case r == 'H' || r == 'W':
+			// H and W don't separate letters with the same code.
+		case digit == 0:
+			last = 0
+		case digit != last:
+			code = append(code, digit)
+			last = digit
+		}
+
+		if len(code) == 4 {
+			break
+		}
+	}
+
+	for len(code) > 0 && len(code) < 4 {
+		code = append(code, '0')
+	}
+
+	return string(code)
+}
+
+// maxEdits is how many edits a misspelling of s may have: none for words
+// too short to tell apart, 1 for short words and 2 for longer ones.
+func maxEdits(s string) int {
+	switch n := len([]rune(s)); {
+	case n < 4:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// fuzzyMatch finds the word of dictionary closest to s, returning the
+// correction it maps to. A match has to start with the same letter and be
+// within maxEdits of s, and 2 edits away it also has to sound the same.
+// Nothing is returned when the closest words lead to different corrections.
+func fuzzyMatch(s string, dictionary map[string]string) (string, bool) {
+	limit := maxEdits(s)
+	if limit == 0 {
+		return "", false
+	}
+
+	code := soundex(s)
+	best, bestDistance, bestSounds, ambiguous := "", limit+1, false, false
+	for word, correction := range dictionary {
+		if word == "" || word[0] != s[0] {
+			continue
+		}
+
+		distance := editDistance(s, word)
+		sounds := soundex(word) == code
+		if distance > limit || (distance > 1 && !sounds) {
+			continue
+		}
+
+		switch {
+		case distance < bestDistance, distance == bestDistance && sounds && !bestSounds:
+			best, bestDistance, bestSounds, ambiguous = correction, distance, sounds, false
+		case distance == bestDistance && sounds == bestSounds && correction != best:
+			ambiguous = true
+		}
+	}
+
+	if best == "" || ambiguous {
+		return "", false
+	}
+
+	return best, true
+}
@@ -0,0 +1,14 @@
+module github.com/ecarter202/godress
+
+go 1.21
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/fatih/color v1.18.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	golang.org/x/sys v0.25.0 // indirect
+)
@@ -0,0 +1,127 @@
+package godress
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// CanonicalKeyVersion is the version of the rules CanonicalKey follows. It
+// is part of every Hash, so hashes made under different rules never match.
+const CanonicalKeyVersion = 1
+
+// HashAlgorithm is the hash function an address' Hash is made with.
+type HashAlgorithm string
+
+// Hash algorithms.
+const (
+	// HashSHA256 is SHA-256, the default.
+	HashSHA256 HashAlgorithm = "sha256"
+	// HashXXHash is the 64 bit xxHash, which is shorter and faster but
+	// not meant to resist tampering.
+	HashXXHash HashAlgorithm = "xxh64"
+)
+
+// CanonicalKey returns a key that is the same for every way of writing an
+// address. It is built from the standardized parts of the street line,
+// with directions, street types and unit designators abbreviated, followed
+// by the 5 digit zip code, or the city and state without one. Parts that
+// don't change where mail goes, like the +4 add-on or the county, are
+// left out.
+func CanonicalKey(a *Address) string {
+	var street []string
+	switch {
+	case a.Kind == KindMilitary:
+		street = []string{formatMilitary(a.MilitaryUnitType, a.MilitaryUnit, a.Box)}
+	case a.Kind.isRoute():
+		street = []string{formatRoute(a.Kind, a.Route, a.Box)}
+	case a.Kind == KindPoBox:
+		street = []string{"PO BOX", a.Box}
+	default:
+		street = []string{
+			a.HouseNumber,
+			directionAbbr(a.PreDirectional),
+			a.StreetPreType,
+			a.StreetName,
+			StreetTypeAbbr(a.StreetType),
+			directionAbbr(a.PostDirectional),
+			canonicalUnits(a),
+		}
+	}
+
+	place := []string{a.City, StateAbbreviation(a.State)}
+	if zip, _, _ := splitZipcode(a.PostalCode); zip != "" {
+		place = []string{zip}
+	}
+
+	kind := a.Kind
+	if kind == "" {
+		kind = KindStreet
+	}
+
+	return strings.Join([]string{
+		string(kind),
+		canonical(a.Urbanization),
+		canonical(street...),
+		canonical(place...),
+	}, "|")
+}
+
+// HashAddress hashes the canonical key of an address with algorithm,
+// returning it prefixed by the key's version and the algorithm, i.e.
+// v1:sha256:9f86d0...
+func HashAddress(a *Address, algorithm HashAlgorithm) string {
+	key := []byte(CanonicalKey(a))
+
+	var sum string
+	switch algorithm {
+	case HashXXHash:
+		sum = fmt.Sprintf("%016x", xxhash.Sum64(key))
+	default:
+		algorithm = HashSHA256
+		sum = fmt.Sprintf("%x", sha256.Sum256(key))
+	}
+
+	return fmt.Sprintf("v%d:%s:%s", CanonicalKeyVersion, algorithm, sum)
+}
+
+// canonical uppercases and joins parts, collapsing their whitespace.
+func canonical(parts ...string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(strings.Join(parts, " ")), " "))
+}
+
+// canonicalUnits returns the secondary units of an address with their
+// designators abbreviated, i.e. BLDG 3 APT 12.
+func canonicalUnits(a *Address) string {
+	units := a.Units
+	if len(units) == 0 && a.Unit != "" {
+		units = []SecondaryUnit{{Designator: a.UnitDesignator, Value: a.Unit}}
+	}
+
+	parts := make([]string, 0, 2*len(units))
+	for _, unit := range units {
+		parts = append(parts, unitAbbr(unit.Designator), strings.TrimPrefix(unit.Value, "#"))
+	}
+
+	return canonical(parts...)
+}
+
+// directionAbbr abbreviates an English or Spanish street direction.
+func directionAbbr(s string) string {
+	if abbr, ok := spanishDirections[strings.ToUpper(s)]; ok {
+		return abbr
+	}
+
+	return StreetDirectionAbbr(s)
+}
+
+// unitAbbr abbreviates a secondary unit designator, i.e. Apartment to APT.
+func unitAbbr(s string) string {
+	if term, ok := unitTerms[strings.ToUpper(s)]; ok {
+		return term.Abbreviation
+	}
+
+	return s
+}
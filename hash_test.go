@@ -0,0 +1,57 @@
+package godress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	same := [][]string{
+		{"123 North Center Street, Lehi, UT 84043", "123 N Center St Lehi UT 84043-1234", "123 n. center st., lehi, utah 84043"},
+		{"123 Main St Apartment 4, Lehi, UT 84043", "123 Main St Apt 4 Lehi UT 84043"},
+		{"123 Main St, Lehi, UT", "123 Main Street Lehi Utah"},
+		{"PO Box 123, Lehi, UT 84043", "P.O. Box 123 Lehi UT 84043"},
+		{"137 N 800 E Spanish Fork, UT 84660", "137 North 800 East St Spanish Fork UT 84660"},
+	}
+	for _, addresses := range same {
+		expected := MustParse(addresses[0])
+		for _, s := range addresses[1:] {
+			if a := MustParse(s); a.Hash != expected.Hash {
+				t.Errorf("expected %q to hash like %q, got keys %q and %q", s, addresses[0], CanonicalKey(a), CanonicalKey(expected))
+			}
+		}
+	}
+
+	different := []string{
+		"123 N Center St Lehi UT 84043",
+		"123 S Center St Lehi UT 84043",
+		"123 N Center Ave Lehi UT 84043",
+		"123 N Center St Apt 4 Lehi UT 84043",
+		"123 N Center St Lehi UT 84045",
+		"PO Box 123 Lehi UT 84043",
+	}
+	seen := map[string]string{}
+	for _, s := range different {
+		hash := MustParse(s).Hash
+		if prev, ok := seen[hash]; ok {
+			t.Errorf("expected %q and %q to hash differently", s, prev)
+		}
+		seen[hash] = s
+	}
+
+	a := MustParse("123 N Center St Lehi UT 84043")
+	if !strings.HasPrefix(a.Hash, "v1:sha256:") || len(a.Hash) != len("v1:sha256:")+64 {
+		t.Errorf("expected a versioned SHA-256 hash, got %s", a.Hash)
+	}
+
+	x, _ := NewParser(WithHashAlgorithm(HashXXHash)).Parse("123 N Center St Lehi UT 84043")
+	if !strings.HasPrefix(x.Hash, "v1:xxh64:") || len(x.Hash) != len("v1:xxh64:")+16 {
+		t.Errorf("expected a versioned xxHash, got %s", x.Hash)
+	} else if x.Hash != HashAddress(a, HashXXHash) {
+		t.Errorf("expected HashAddress to match the parser, got %s", HashAddress(a, HashXXHash))
+	}
+
+	if key := CanonicalKey(a); key != "street||123 N CENTER ST|84043" {
+		t.Errorf("unexpected canonical key %q", key)
+	}
+}
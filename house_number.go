@@ -0,0 +1,108 @@
+package godress
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// houseNumberRegex matches a number with an optional letter, i.e. 123A
+	// or 123-A, or a hyphenated number, i.e. 37-40 or 100-110.
+	houseNumberRegex = regexp.MustCompile(`(?i)^(\d+)(?:-?([A-Z])|-(\d+))?$`)
+	// gridHouseNumberRegex matches Wisconsin grid numbers, i.e. N6W23001.
+	gridHouseNumberRegex = regexp.MustCompile(`(?i)^[NSEW]\d+[NSEW]\d+$`)
+	fractionRegex        = regexp.MustCompile(`^\d+/\d+$`)
+
+	// rangeWords join the two numbers of a house number range, i.e. 100 TO
+	// 110.
+	rangeWords = map[string]bool{"TO": true, "THRU": true, "THROUGH": true}
+)
+
+// houseNumber is a house number split into its parts.
+type houseNumber struct {
+	base     string
+	suffix   string
+	fraction string
+	rangeEnd string
+}
+
+// isHouseNumber reports whether s is shaped like a house number, leaving
+// out fractions, which only follow one.
+func isHouseNumber(s string) bool {
+	return houseNumberRegex.MatchString(s) || gridHouseNumberRegex.MatchString(s)
+}
+
+// houseNumberAt returns how many tokens at the start of tokens spell a
+// house number, i.e. 2 for 123 1/2 and 3 for 100 TO 110, or 0 when they
+// don't.
+func houseNumberAt(tokens []*token) int {
+	if len(tokens) == 0 || !isHouseNumber(tokens[0].text) {
+		return 0
+	}
+
+	if len(tokens) > 3 && !tokens[0].comma && !tokens[1].comma && isDigits(tokens[0].text) &&
+		rangeWords[tokens[1].text] && isDigits(tokens[2].text) {
+		return 3
+	}
+
+	if len(tokens) > 2 && !tokens[0].comma && fractionRegex.MatchString(tokens[1].text) {
+		return 2
+	}
+
+	return 1
+}
+
+// splitHouseNumber splits a house number into its parts. Numbers joined
+// by a range word, like 100 TO 110, are a range. A hyphenated number is a
+// single number, like 37-40 in Queens, unless hyphenRanges is set, when it
+// is a range if the second number is larger and as long as the first, like
+// 100-110.
+func splitHouseNumber(s string, hyphenRanges bool) (h houseNumber) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return
+	} else if len(fields) == 3 && rangeWords[strings.ToUpper(fields[1])] {
+		h.base, h.rangeEnd = fields[0], fields[2]
+		return
+	} else if len(fields) > 1 {
+		h.fraction = fields[1]
+	}
+
+	m := houseNumberRegex.FindStringSubmatch(fields[0])
+	if m == nil {
+		h.base = fields[0]
+		return
+	}
+
+	h.base, h.suffix = m[1], m[2]
+	if m[3] != "" && hyphenRanges && len(m[3]) == len(m[1]) && m[3] > m[1] {
+		h.rangeEnd = m[3]
+	} else if m[3] != "" {
+		h.base = m[1] + "-" + m[3]
+	}
+
+	return
+}
+
+// isDigits reports whether s is made of digits only.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// setHouseNumber sets the house number of an address along with its parts,
+// splitting hyphenated ranges when hyphenRanges is set.
+func (a *Address) setHouseNumber(s string, hyphenRanges bool) {
+	h := splitHouseNumber(s, hyphenRanges)
+
+	a.HouseNumber = s
+	a.HouseNumberBase = h.base
+	a.HouseNumberSuffix = h.suffix
+	a.HouseNumberFraction = h.fraction
+	a.HouseNumberRangeEnd = h.rangeEnd
+}
@@ -0,0 +1,53 @@
+package godress
+
+import "testing"
+
+func TestParseHouseNumbers(t *testing.T) {
+	tests := map[string][5]string{
+		"123A Main St Lehi, UT 84043":         {"123A", "123", "A", "", ""},
+		"123-B Main St Lehi, UT 84043":        {"123-B", "123", "B", "", ""},
+		"123 1/2 Main St Lehi, UT 84043":      {"123 1/2", "123", "", "1/2", ""},
+		"45-17 21st St, Queens, NY 11101":     {"45-17", "45-17", "", "", ""},
+		"37-40 79th St, Queens, NY 11372":     {"37-40", "37-40", "", "", ""},
+		"31-25 38th St, Astoria, NY 11103":    {"31-25", "31-25", "", "", ""},
+		"100-110 Main St Lehi, UT 84043":      {"100-110", "100-110", "", "", ""},
+		"100 to 110 Main St Lehi, UT 84043":   {"100 TO 110", "100", "", "", "110"},
+		"100 Thru 110 Main St Lehi, UT 84043": {"100 THRU 110", "100", "", "", "110"},
+		"N6W23001 Bluemound Rd, Waukesha, WI": {"N6W23001", "N6W23001", "", "", ""},
+		"7 1st Ave, New York, NY 10003":       {"7", "7", "", "", ""},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := [5]string{a.HouseNumber, a.HouseNumberBase, a.HouseNumberSuffix, a.HouseNumberFraction, a.HouseNumberRangeEnd}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	ranges := map[string][5]string{
+		"100-110 Main St Lehi, UT 84043":  {"100-110", "100", "", "", "110"},
+		"45-17 21st St, Queens, NY 11101": {"45-17", "45-17", "", "", ""},
+	}
+
+	p := NewParser(WithHyphenatedRanges())
+	for s, expected := range ranges {
+		a, err := p.Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := [5]string{a.HouseNumber, a.HouseNumberBase, a.HouseNumberSuffix, a.HouseNumberFraction, a.HouseNumberRangeEnd}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	if a, _ := Parse("21st St, Queens, NY 11101"); a.HouseNumber != "" {
+		t.Errorf("expected an ordinal not to be a house number, got %q", a.HouseNumber)
+	}
+}
@@ -0,0 +1,167 @@
+// Command zipgen converts the GeoNames US postal code dump into the csv
+// format of data/zipcodes_sample.csv, so a full zip code table can be
+// built for LoadZipData or embedded in place of the sample:
+//
+//	curl -O https://download.geonames.org/export/zip/US.zip
+//	unzip US.zip US.txt
+//	go run ./internal/zipgen -o data/zipcodes.csv US.txt
+//
+// GeoNames is licensed under CC BY 4.0. It has no zip code types or time
+// zones, so zip codes of the military states are typed military and the
+// rest standard, and the timezone column is left blank. The first place
+// name of a zip code is its primary city, and the others its acceptable
+// cities.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// stateFIPS are the FIPS codes of the states, which GeoNames leaves off
+// its county codes.
+var stateFIPS = map[string]string{
+	"AL": "01", "AK": "02", "AZ": "04", "AR": "05", "CA": "06", "CO": "08",
+	"CT": "09", "DE": "10", "DC": "11", "FL": "12", "GA": "13", "HI": "15",
+	"ID": "16", "IL": "17", "IN": "18", "IA": "19", "KS": "20", "KY": "21",
+	"LA": "22", "ME": "23", "MD": "24", "MA": "25", "MI": "26", "MN": "27",
+	"MS": "28", "MO": "29", "MT": "30", "NE": "31", "NV": "32", "NH": "33",
+	"NJ": "34", "NM": "35", "NY": "36", "NC": "37", "ND": "38", "OH": "39",
+	"OK": "40", "OR": "41", "PA": "42", "RI": "44", "SC": "45", "SD": "46",
+	"TN": "47", "TX": "48", "UT": "49", "VT": "50", "VA": "51", "WA": "53",
+	"WV": "54", "WI": "55", "WY": "56", "AS": "60", "GU": "66", "MP": "69",
+	"PR": "72", "VI": "78",
+}
+
+var militaryStates = map[string]bool{"AA": true, "AE": true, "AP": true}
+
+// zipRow is a zip code of the output, gathered from the GeoNames lines.
+type zipRow struct {
+	zip, state, county, countyFIPS string
+	latitude, longitude            string
+	cities                         []string
+}
+
+func main() {
+	out := flag.String("o", "", "file to write the csv to, instead of stdout")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: zipgen [-o file] US.txt")
+		os.Exit(2)
+	}
+
+	in, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fail(err)
+	}
+	defer in.Close()
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := convert(in, w); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "zipgen:", err)
+	os.Exit(1)
+}
+
+// convert reads the tab separated GeoNames lines from r, and writes the
+// zip codes to w as csv, sorted by zip code.
+func convert(r io.Reader, w io.Writer) error {
+	rows := map[string]*zipRow{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		// country, postal code, place, state name, state, county,
+		// county code, community, community code, latitude, longitude,
+		// accuracy
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 12 {
+			return fmt.Errorf("line %d has %d fields, want 12", n, len(fields))
+		}
+
+		zip, city := fields[1], strings.ToUpper(strings.TrimSpace(fields[2]))
+		row, ok := rows[zip]
+		if !ok {
+			row = &zipRow{
+				zip:       zip,
+				state:     fields[4],
+				county:    strings.ToUpper(fields[5]),
+				latitude:  fields[9],
+				longitude: fields[10],
+			}
+			if fips, ok := stateFIPS[row.state]; ok && fields[6] != "" {
+				row.countyFIPS = fips + fields[6]
+			}
+			rows[zip] = row
+		}
+		if city != "" && !contains(row.cities, city) {
+			row.cities = append(row.cities, city)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	zips := make([]string, 0, len(rows))
+	for zip := range rows {
+		zips = append(zips, zip)
+	}
+	sort.Strings(zips)
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"zip", "type", "primary_city", "acceptable_cities", "state", "county", "county_fips", "latitude", "longitude", "timezone"})
+	for _, zip := range zips {
+		row := rows[zip]
+
+		typ, county, fips := "standard", row.county, row.countyFIPS
+		if militaryStates[row.state] {
+			typ, county, fips = "military", "", ""
+		}
+
+		var primary string
+		if len(row.cities) > 0 {
+			primary = row.cities[0]
+		}
+		var acceptable []string
+		if len(row.cities) > 1 {
+			acceptable = row.cities[1:]
+		}
+
+		cw.Write([]string{zip, typ, primary, strings.Join(acceptable, ";"), row.state, county, fips, row.latitude, row.longitude, ""})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
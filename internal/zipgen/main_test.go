@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	ap "github.com/ecarter202/godress"
+)
+
+func TestConvert(t *testing.T) {
+	geonames := strings.Join([]string{
+		"US\t84043\tLehi\tUtah\tUT\tUtah\t049\t\t\t40.4068\t-111.8693\t4",
+		"US\t09204\tAPO\t\tAE\t\t\t\t\t\t\t",
+		"US\t00802\tSt Thomas\tVirgin Islands\tVI\tSt Thomas Island\t030\t\t\t18.3419\t-64.9307\t",
+		"US\t00802\tCharlotte Amalie\tVirgin Islands\tVI\tSt Thomas Island\t030\t\t\t18.3419\t-64.9307\t",
+		"",
+	}, "\n")
+
+	var buf bytes.Buffer
+	if err := convert(strings.NewReader(geonames), &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "zip,type,primary_city,acceptable_cities,state,county,county_fips,latitude,longitude,timezone\n" +
+		"00802,standard,ST THOMAS,CHARLOTTE AMALIE,VI,ST THOMAS ISLAND,78030,18.3419,-64.9307,\n" +
+		"09204,military,APO,,AE,,,,,\n" +
+		"84043,standard,LEHI,,UT,UTAH,49049,40.4068,-111.8693,\n"
+	if buf.String() != want {
+		t.Fatalf("convert wrote\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// The csv has to load as zip code data.
+	if err := ap.LoadZipData(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if info, ok := ap.LookupZip("84043"); !ok || info.PrimaryCity != "LEHI" || info.CountyFIPS != "49049" {
+		t.Errorf("LookupZip(84043) = %+v, %v", info, ok)
+	}
+}
+
+func TestConvertShortLine(t *testing.T) {
+	if err := convert(strings.NewReader("US\t84043\tLehi\n"), &bytes.Buffer{}); err == nil {
+		t.Error("convert of a short line succeeded, want an error")
+	}
+}
@@ -0,0 +1,109 @@
+package godress
+
+import "strings"
+
+// AddressKind is the kind of delivery an address is for.
+type AddressKind string
+
+// Address kinds.
+const (
+	KindStreet          AddressKind = "street"
+	KindPoBox           AddressKind = "po_box"
+	KindRuralRoute      AddressKind = "rural_route"
+	KindHighwayContract AddressKind = "highway_contract"
+)
+
+var (
+	// routePrefixes are the spellings of rural and highway contract routes.
+	// Star routes are highway contract routes by another name.
+	routePrefixes = map[string]AddressKind{
+		"RR":                     KindRuralRoute,
+		"R R":                    KindRuralRoute,
+		"RFD":                    KindRuralRoute,
+		"RURAL ROUTE":            KindRuralRoute,
+		"RURAL RTE":              KindRuralRoute,
+		"RURAL RT":               KindRuralRoute,
+		"RURAL FREE DELIVERY":    KindRuralRoute,
+		"HC":                     KindHighwayContract,
+		"HCR":                    KindHighwayContract,
+		"HIGHWAY CONTRACT":       KindHighwayContract,
+		"HIGHWAY CONTRACT ROUTE": KindHighwayContract,
+		"HWY CONTRACT":           KindHighwayContract,
+		"STAR ROUTE":             KindHighwayContract,
+		"STAR RTE":               KindHighwayContract,
+	}
+
+	// routeAbbreviations are the Publication 28 abbreviations of each route kind.
+	routeAbbreviations = map[AddressKind]string{
+		KindRuralRoute:      "RR",
+		KindHighwayContract: "HC",
+	}
+
+	// routeNames are the spelled out names of each route kind.
+	routeNames = map[AddressKind]string{
+		KindRuralRoute:      "RURAL ROUTE",
+		KindHighwayContract: "HIGHWAY CONTRACT ROUTE",
+	}
+)
+
+// isRoute reports whether k is a rural or highway contract route, which
+// are addressed by route and box number instead of a street.
+func (k AddressKind) isRoute() bool {
+	_, ok := routeAbbreviations[k]
+
+	return ok
+}
+
+// hasStreet reports whether k is addressed by house number and street, as
+// street addresses and PO boxes are.
+func (k AddressKind) hasStreet() bool {
+	return k == KindStreet || k == KindPoBox
+}
+
+// IsRuralRoute checks an address string for indication of being on a rural
+// route or highway contract (star) route, i.e. RR 2 Box 15 or HC 68 Box 22.
+func IsRuralRoute(s string) bool {
+	n, _ := routeAt(tokenize(s))
+
+	return n > 0
+}
+
+// routeAt returns how many tokens at the start of tokens spell a route
+// prefix, and its kind, or 0 when they don't. The prefix needs a route
+// number or box number after it.
+func routeAt(tokens []*token) (int, AddressKind) {
+	for n := 3; n > 0; n-- {
+		if n >= len(tokens) {
+			continue
+		}
+
+		words := make([]string, 0, n)
+		for _, t := range tokens[:n] {
+			words = append(words, t.text)
+		}
+
+		if kind, ok := routePrefixes[strings.Join(words, " ")]; ok && (isUnitValue(tokens[n].text) || isBoxKeyword(tokens[n].text)) {
+			return n, kind
+		}
+	}
+
+	return 0, ""
+}
+
+// formatRoute formats a route the way Publication 28 writes it, i.e.
+// RR 2 BOX 15.
+func formatRoute(kind AddressKind, route, box string) string {
+	s := routeAbbreviations[kind]
+	if route != "" {
+		s += " " + route
+	}
+	if box != "" {
+		s += " BOX " + box
+	}
+
+	return s
+}
+
+func isBoxKeyword(s string) bool {
+	return s == "BOX" || s == "BX"
+}
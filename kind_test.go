@@ -0,0 +1,43 @@
+package godress
+
+import "testing"
+
+func TestParseRoutes(t *testing.T) {
+	tests := map[string][4]string{
+		"RR 2 Box 15, Lehi, UT 84043":          {string(KindRuralRoute), "2", "15", "RR 2 BOX 15 LEHI, UT 84043"},
+		"Rural Route 4 Box 120A Lehi UT 84043": {string(KindRuralRoute), "4", "120A", "RR 4 BOX 120A LEHI, UT 84043"},
+		"R.R. 3 Bx 7, Lehi, UT":                {string(KindRuralRoute), "3", "7", "RR 3 BOX 7 LEHI, UT"},
+		"HC 68 Box 22, Lehi, UT":               {string(KindHighwayContract), "68", "22", "HC 68 BOX 22 LEHI, UT"},
+		"Star Route Box 9, Lehi, UT":           {string(KindHighwayContract), "", "9", "HC BOX 9 LEHI, UT"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := [4]string{string(a.Kind), a.Route, a.Box, a.String()}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+		if !IsRuralRoute(s) {
+			t.Errorf("%s: expected a rural route", s)
+		}
+	}
+
+	if _, err := Parse("RR 3, Lehi, UT"); err == nil || err.(*ParseError).Kind != MissingHouseNumber {
+		t.Errorf("expected a route without a box to be missing its house number, got %v", err)
+	}
+
+	if IsRuralRoute("1234 RR 620, Austin, TX") {
+		t.Errorf("expected a ranch road not to be a rural route")
+	}
+
+	s, err := ParseStreet("Rural Route 4 Box 120A")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.Kind != KindRuralRoute || s.String() != "RR 4 BOX 120A" {
+		t.Errorf("expected RR 4 BOX 120A, got %q", s.String())
+	}
+}
@@ -0,0 +1,715 @@
+package godress
+
+import "strings"
+
+// label is the address part a token was assigned to.
+type label int
+
+const (
+	labelNone label = iota
+	labelUrbanization
+	labelHouseNumber
+	labelPreDirectional
+	labelStreetPreType
+	labelStreetName
+	labelStreetType
+	labelPostDirectional
+	labelUnitDesignator
+	labelUnit
+	labelCity
+	labelState
+	labelPostalCode
+	labelRoute
+	labelBox
+	labelMilitaryUnitType
+	labelMilitaryUnit
+	labelIgnored
+)
+
+// rule is the heuristic that assigned a token its label.
+type rule int
+
+const (
+	ruleNone rule = iota
+	// rulePosition labels a token only by where it appears.
+	rulePosition
+	// ruleBoundary labels a token by position, bounded by a street type,
+	// direction, state or comma.
+	ruleBoundary
+	// ruleKeyword labels a token that follows a keyword, like APT or BOX.
+	ruleKeyword
+	// ruleDictionary labels a token found in one of the dictionaries.
+	ruleDictionary
+	// rulePattern labels a token by its shape, like a number or zip code.
+	rulePattern
+)
+
+// token is a single word of an address.
+type token struct {
+	raw   string
+	text  string
+	comma bool
+	label label
+	rule  rule
+}
+
+func (t *token) set(l label, r rule) {
+	t.label = l
+	t.rule = r
+}
+
+// tokenize splits s into tokens, remembering which ones were followed by a comma.
+func tokenize(s string) (tokens []*token) {
+	for _, field := range strings.Fields(s) {
+		for i, part := range strings.Split(field, ",") {
+			if i > 0 && len(tokens) > 0 {
+				tokens[len(tokens)-1].comma = true
+			}
+
+			part = strings.Replace(part, ".", "", -1)
+			if len(part) > 1 && part[0] == '#' {
+				tokens = append(tokens, &token{raw: "#", text: "#"})
+				part = part[1:]
+			}
+
+			if route := splitPreType(part); route != nil {
+				tokens = append(tokens, &token{raw: route[0], text: strings.ToUpper(route[0])})
+				part = route[1]
+			}
+
+			if part != "" {
+				tokens = append(tokens, &token{raw: part, text: strings.ToUpper(part)})
+			}
+		}
+	}
+
+	return
+}
+
+// labelOptions override the greedy choices made while labeling, so other
+// readings of an ambiguous address can be tried.
+type labelOptions struct {
+	// forceState makes state decide whether the tokens before the zip
+	// code are a state, when they could also be a street type.
+	forceState bool
+	state      bool
+	// forceSplit makes typeIdx and nameEnd decide where the street name
+	// ends, as indexes into the street tokens. typeIdx is -1 for no type.
+	forceSplit bool
+	typeIdx    int
+	nameEnd    int
+}
+
+// labeling is the outcome of labeling a list of tokens.
+type labeling struct {
+	p      *Parser
+	tokens []*token
+	opts   labelOptions
+	kind   AddressKind
+	err    *ParseError
+	// street holds the tokens between the house number and the state,
+	// and nameStart is where the street name starts within them.
+	street    []*token
+	nameStart int
+	// preType is the street type found before the street name, if any.
+	preType *streetPreType
+	// spanish is set for Puerto Rico addresses, which have Spanish street
+	// types and directions.
+	spanish bool
+	// grid is set when the street is a grid coordinate, like 800 E.
+	grid bool
+	// ambiguousState is set when the state could be a street type.
+	ambiguousState bool
+}
+
+func (l *labeling) fail(kind ParseErrorKind, token string) {
+	if l.err == nil {
+		l.err = &ParseError{Kind: kind, Token: token}
+	}
+}
+
+// label labels tokens, greedily except for the choices overridden by opts.
+// When street is true only the parts of a street are looked for, there is
+// no city, state or zip code.
+func (p *Parser) label(tokens []*token, street bool, opts labelOptions) *labeling {
+	l := &labeling{p: p, tokens: tokens, opts: opts, kind: KindStreet}
+	start := l.labelUrbanization()
+
+	if isPoBoxTokens(tokens) {
+		l.kind = KindPoBox
+		start = l.labelPoBox()
+	} else if n, kind := routeAt(tokens); n > 0 {
+		l.kind = kind
+		start = l.labelRoute(n)
+	} else if militaryAt(tokens) > 0 {
+		l.kind = KindMilitary
+		start = l.labelMilitary()
+	} else if n := houseNumberAt(tokens[start:]); n > 0 {
+		for _, t := range tokens[start : start+n] {
+			t.set(labelHouseNumber, rulePattern)
+		}
+		start += n
+	}
+
+	end := len(tokens)
+	if !street {
+		end = start + l.labelTail(tokens[start:])
+		l.spanish = l.spanish || l.inPuertoRico()
+	}
+
+	var rest []*token
+	if l.kind != KindStreet {
+		rest = tokens[start:end]
+	} else {
+		rest = l.labelStreet(tokens[start:end], street)
+	}
+
+	for _, t := range rest {
+		if street {
+			t.set(labelStreetName, rulePosition)
+		} else if end < len(tokens) {
+			t.set(labelCity, ruleBoundary)
+		} else {
+			t.set(labelCity, rulePosition)
+		}
+	}
+
+	return l
+}
+
+// labelPoBox labels the PO BOX keyword and box number, returning the
+// index of the first token after them.
+func (l *labeling) labelPoBox() int {
+	for i, t := range l.tokens {
+		if t.text == "PO" || t.text == "P" || t.text == "O" {
+			t.set(labelIgnored, ruleDictionary)
+			continue
+		}
+
+		if t.text == "BOX" || t.text == "POBOX" {
+			t.set(labelIgnored, ruleDictionary)
+			if i+1 < len(l.tokens) && isInt(l.tokens[i+1].text) {
+				l.tokens[i+1].set(labelHouseNumber, ruleKeyword)
+				return i + 2
+			}
+
+			return i + 1
+		}
+
+		break
+	}
+
+	return 0
+}
+
+// labelRoute labels a rural or highway contract route, whose prefix is
+// the first n tokens, along with its box number, returning the index of
+// the first token after them.
+func (l *labeling) labelRoute(n int) int {
+	for _, t := range l.tokens[:n] {
+		t.set(labelIgnored, ruleDictionary)
+	}
+
+	i := n
+	if i < len(l.tokens) && !isBoxKeyword(l.tokens[i].text) {
+		l.tokens[i].set(labelRoute, ruleKeyword)
+		i++
+	}
+
+	if i+1 < len(l.tokens) && isBoxKeyword(l.tokens[i].text) && isUnitValue(l.tokens[i+1].text) {
+		l.tokens[i].set(labelIgnored, ruleDictionary)
+		l.tokens[i+1].set(labelBox, ruleKeyword)
+		i += 2
+	}
+
+	return i
+}
+
+// labelTail labels the state and zip code at the end of tokens,
+// returning the index where they start.
+func (l *labeling) labelTail(tokens []*token) int {
+	end := len(tokens)
+	if end < 2 {
+		return end
+	}
+
+	var zip []*token
+	if n := zipAt(tokens, end); n > 0 {
+		zip = tokens[end-n : end]
+		end -= n
+	} else if last := tokens[end-1]; looksLikeZipcode(last.text) && stateAt(tokens, end-1) > 0 {
+		l.fail(InvalidZip, last.text)
+		zip = tokens[end-1 : end]
+		end--
+	}
+
+	if n := stateAt(tokens, end); n > 0 && end-n > 0 {
+		state := tokens[end-1]
+		hasType := false
+		for _, t := range tokens[:end-n] {
+			_, preType := streetPreTypesByAlias[t.text]
+			_, spanishPreType := spanishPreTypesByAlias[t.text]
+			hasType = hasType || l.p.isStreetType(t.text) || preType || spanishPreType
+		}
+
+		isState := zip != nil || tokens[end-n-1].comma || !l.p.isStreetType(state.text)
+		l.ambiguousState = !isState
+		if l.opts.forceState {
+			isState = l.opts.state
+		} else {
+			isState = isState || hasType
+		}
+
+		if isState {
+			for _, t := range tokens[end-n : end] {
+				t.set(labelState, ruleDictionary)
+			}
+			end -= n
+		}
+	} else if zip != nil && end > 0 && isStateLike(tokens[end-1].text) {
+		l.fail(UnknownState, tokens[end-1].text)
+	}
+
+	for _, t := range zip {
+		t.set(labelPostalCode, rulePattern)
+	}
+
+	return end
+}
+
+// zipAt returns how many tokens ending just before end spell a zip code,
+// which may be written with its +4 add-on and delivery point apart, as in
+// 84043 1234, or 0 when they don't.
+func zipAt(tokens []*token, end int) int {
+	for n := 3; n > 0; n-- {
+		if end-n < 1 {
+			continue
+		}
+
+		words := make([]string, 0, n)
+		for i, t := range tokens[end-n : end] {
+			if t.comma && i < n-1 {
+				break
+			}
+			words = append(words, t.text)
+		}
+
+		if len(words) == n && IsZipcode(strings.Join(words, " ")) {
+			return n
+		}
+	}
+
+	return 0
+}
+
+// labelStreet labels the direction, name, type and unit of a street at the
+// start of tokens, returning the tokens left over.
+func (l *labeling) labelStreet(tokens []*token, street bool) []*token {
+	i := 0
+	if l.isPreDirectionalAt(tokens) {
+		tokens[0].set(labelPreDirectional, ruleDictionary)
+		i = 1
+	}
+
+	if n, preType := l.preTypeAt(tokens, i); n > 0 {
+		for _, t := range tokens[i : i+n] {
+			t.set(labelStreetPreType, ruleDictionary)
+		}
+		l.preType = preType
+		i += n
+	}
+
+	nameStart := i
+	l.street, l.nameStart = tokens, nameStart
+
+	if n := l.gridAt(tokens, i); n > 0 && l.preType == nil && !l.opts.forceSplit {
+		l.grid = true
+		tokens[i].set(labelStreetName, rulePattern)
+		tokens[i+1].set(labelPostDirectional, ruleDictionary)
+		if n == 3 {
+			// A grid street is named by its coordinate alone, ST adds nothing.
+			tokens[i+2].set(labelIgnored, ruleDictionary)
+		}
+
+		return l.labelUnits(tokens, i+n)
+	}
+
+	typeIdx := l.findStreetType(tokens, nameStart)
+	stop := l.streetNameEnd(tokens, nameStart, typeIdx, street)
+	if l.preType != nil && l.preType.numbered && typeIdx < 0 && stop > nameStart+1 && !street {
+		// Without a street type, a route is named by its number alone.
+		stop = nameStart + 1
+	}
+	if l.opts.forceSplit {
+		typeIdx, stop = l.opts.typeIdx, l.opts.nameEnd
+		if typeIdx >= 0 {
+			stop = typeIdx
+		}
+	}
+
+	for ; i < stop; i++ {
+		if stop < len(tokens) || street {
+			tokens[i].set(labelStreetName, ruleBoundary)
+		} else {
+			tokens[i].set(labelStreetName, rulePosition)
+		}
+	}
+
+	if i < len(tokens) && i == typeIdx {
+		tokens[i].set(labelStreetType, ruleDictionary)
+		i++
+	}
+
+	if i < len(tokens) && i > nameStart && l.isPostDirectionalAt(tokens, i) {
+		tokens[i].set(labelPostDirectional, ruleDictionary)
+		i++
+	}
+
+	return l.labelUnits(tokens, i)
+}
+
+// labelUnits labels the secondary units starting at tokens[i], returning
+// the tokens left over.
+func (l *labeling) labelUnits(tokens []*token, i int) []*token {
+	for i < len(tokens) && l.isUnitAt(tokens, i, true) {
+		tokens[i].set(labelUnitDesignator, ruleDictionary)
+		if !l.p.unitTerm(tokens[i].text).NoRange {
+			tokens[i+1].set(labelUnit, ruleKeyword)
+			i++
+		}
+		i++
+	}
+
+	return tokens[i:]
+}
+
+// gridAt returns how many tokens starting at tokens[i] spell a grid street,
+// like 800 E or 800 East St in Utah, or 0 when they don't.
+func (l *labeling) gridAt(tokens []*token, i int) int {
+	if i+1 >= len(tokens) || tokens[i].comma || !isDigits(tokens[i].text) || !l.isDirection(tokens[i+1].text) {
+		return 0
+	}
+
+	if j := i + 2; j < len(tokens) && !tokens[i+1].comma && StreetTypeAbbr(tokens[j].text) == "St" &&
+		(j+1 == len(tokens) || tokens[j].comma || l.isUnitAt(tokens, j+1, true)) {
+		return 3
+	}
+
+	return 2
+}
+
+// isPreDirectionalAt reports whether tokens starts with a direction before
+// the street name. In "N ST" the direction is the name.
+func (l *labeling) isPreDirectionalAt(tokens []*token) bool {
+	if len(tokens) < 2 || !l.isDirection(tokens[0].text) {
+		return false
+	}
+
+	if n, _ := l.preTypeAt(tokens, 1); n > 0 {
+		return true
+	}
+
+	return !l.p.isStreetType(tokens[1].text) || (len(tokens) > 2 && l.p.isStreetType(tokens[2].text))
+}
+
+// preTypeAt returns how many tokens starting at tokens[i] spell a street
+// pre type, and the pre type, or 0 when they don't. A numbered pre type
+// needs a route number after it, others need a name after it that doesn't
+// end in a street type, so AVENUE B is a pre type and AVE MARIA DR isn't.
+func (l *labeling) preTypeAt(tokens []*token, i int) (int, *streetPreType) {
+	for n := streetPreTypeWords; n > 0; n-- {
+		if i+n >= len(tokens) {
+			continue
+		}
+
+		words := make([]string, 0, n)
+		for _, t := range tokens[i : i+n] {
+			words = append(words, t.text)
+			if t.comma {
+				break
+			}
+		}
+		if len(words) < n {
+			continue
+		}
+
+		preType, ok := l.preTypeAlias(strings.Join(words, " "))
+		if !ok {
+			continue
+		}
+
+		next := tokens[i+n].text
+		if preType.numbered && isUnitValue(next) {
+			return n, preType
+		} else if !preType.numbered && !l.p.isStreetType(next) && !l.isUnitAt(tokens, i+n, false) &&
+			l.findStreetType(tokens, i+n) < 0 {
+			return n, preType
+		}
+	}
+
+	return 0, nil
+}
+
+// isPostDirectionalAt reports whether tokens[i] is a direction after the
+// street name. Spelled out directions are often part of a name, like the
+// city West Jordan, so they need to end the street or follow a number.
+func (l *labeling) isPostDirectionalAt(tokens []*token, i int) bool {
+	t := tokens[i]
+	if !l.isDirection(t.text) {
+		return false
+	} else if len(t.text) <= 2 {
+		return true
+	}
+
+	return i+1 == len(tokens) || t.comma ||
+		(i > 0 && strings.ContainsAny(tokens[i-1].text, "0123456789")) ||
+		l.isUnitAt(tokens, i+1, true)
+}
+
+// isUnitAt reports whether a secondary unit starts at tokens[i]. Designators
+// like APT need a unit number after them. Ones without a number, like REAR,
+// are only recognized after the street and before a comma, another unit or
+// the state.
+func (l *labeling) isUnitAt(tokens []*token, i int, afterStreet bool) bool {
+	term := l.p.unitTerm(tokens[i].text)
+	if term == nil {
+		return false
+	}
+
+	if !term.NoRange {
+		return i+1 < len(tokens) && isUnitValue(tokens[i+1].text)
+	}
+
+	return afterStreet && (i+1 == len(tokens) || tokens[i].comma || l.isUnitAt(tokens, i+1, true))
+}
+
+// findStreetType finds the token most likely to be the street type,
+// returning -1 when there isn't one. A street type needs a name before it,
+// and is skipped in favor of a following street type that is at least as
+// strong, so "SPRING LAKE RD" is named "SPRING LAKE".
+func (l *labeling) findStreetType(tokens []*token, nameStart int) int {
+	for i := nameStart + 1; i < len(tokens); i++ {
+		t := tokens[i]
+		if l.isPostDirectionalAt(tokens, i) || l.isUnitAt(tokens, i, false) {
+			break
+		}
+
+		if l.p.isStreetType(t.text) {
+			if i+1 < len(tokens) && !t.comma && l.p.isStreetType(tokens[i+1].text) &&
+				l.p.streetTypeStrength(tokens[i+1].text) >= l.p.streetTypeStrength(t.text) {
+				continue
+			}
+
+			return i
+		}
+
+		if t.comma {
+			break
+		}
+	}
+
+	return -1
+}
+
+// streetNameEnd returns the index of the first token after the street name.
+func (l *labeling) streetNameEnd(tokens []*token, nameStart, typeIdx int, street bool) int {
+	if typeIdx >= 0 {
+		return typeIdx
+	}
+
+	for i := nameStart; i < len(tokens); i++ {
+		if i > nameStart && (l.isPostDirectionalAt(tokens, i) || l.isUnitAt(tokens, i, false)) {
+			return i
+		}
+
+		if tokens[i].comma {
+			return i + 1
+		}
+	}
+
+	if street || nameStart >= len(tokens) {
+		return len(tokens)
+	} else if l.preType != nil && !l.preType.numbered {
+		// A name after a pre type often has more than one word, like OF
+		// THE AMERICAS, so it runs up to the city.
+		return l.cityStart(tokens, nameStart)
+	}
+
+	return nameStart + 1
+}
+
+// cityStart returns where the city starts in tokens, after a street name
+// starting at nameStart: at the longest known city of the zip code, or of
+// the state, that tokens end with, or else at the last token. Without a
+// state or zip code to tell a city by, the name runs to the end.
+func (l *labeling) cityStart(tokens []*token, nameStart int) int {
+	var state, zip []string
+	for _, t := range l.tokens {
+		switch t.label {
+		case labelState:
+			state = append(state, t.text)
+		case labelPostalCode:
+			zip = append(zip, t.text)
+		}
+	}
+	if len(state) == 0 && len(zip) == 0 {
+		return len(tokens)
+	}
+
+	info, hasZip := LookupZip(strings.Join(zip, "-"))
+	for i := nameStart + 1; i < len(tokens); i++ {
+		words := make([]string, 0, len(tokens)-i)
+		for _, t := range tokens[i:] {
+			words = append(words, t.text)
+		}
+
+		city := strings.Join(words, " ")
+		if (hasZip && zipHasCity(info, city)) || (!hasZip && len(ZipsForCity(city, strings.Join(state, " "))) > 0) {
+			return i
+		}
+	}
+
+	if len(tokens) > nameStart+1 {
+		return len(tokens) - 1
+	}
+
+	return len(tokens)
+}
+
+// stateAt returns how many tokens ending just before end spell a state,
+// or 0 when they don't.
+func stateAt(tokens []*token, end int) int {
+	for n := 3; n > 0; n-- {
+		if end-n < 0 {
+			continue
+		}
+
+		words := make([]string, 0, n)
+		for _, t := range tokens[end-n : end] {
+			words = append(words, t.text)
+		}
+
+		if IsState(strings.ToLower(strings.Join(words, " "))) {
+			return n
+		}
+	}
+
+	return 0
+}
+
+func isPoBoxTokens(tokens []*token) bool {
+	var words []string
+	for _, t := range tokens {
+		words = append(words, t.text)
+	}
+
+	return IsPoBox(strings.Join(words, " "))
+}
+
+// assemble builds an address from labeled tokens.
+func (l *labeling) assemble(a *Address, raw bool) {
+	parts := map[label][]string{}
+	for _, t := range l.tokens {
+		if raw {
+			parts[t.label] = append(parts[t.label], t.raw)
+		} else {
+			parts[t.label] = append(parts[t.label], t.text)
+		}
+	}
+
+	join := func(lbl label) string {
+		return strings.Join(parts[lbl], " ")
+	}
+
+	a.Urbanization = join(labelUrbanization)
+	a.setHouseNumber(join(labelHouseNumber), l.p.hyphenRanges)
+	a.PreDirectional = join(labelPreDirectional)
+	a.PostDirectional = join(labelPostDirectional)
+	a.StreetDirection = a.PreDirectional
+	if a.StreetDirection == "" {
+		a.StreetDirection = a.PostDirectional
+	}
+	a.StreetPreType = join(labelStreetPreType)
+	if l.preType != nil && raw {
+		a.StreetPreType = l.preType.title
+	} else if l.preType != nil {
+		a.StreetPreType = l.preType.standard
+	}
+	a.StreetName = join(labelStreetName)
+	a.StreetType = join(labelStreetType)
+	if l.grid {
+		a.GridCoordinate = a.StreetName
+		a.GridDirection = StreetDirectionAbbr(a.PostDirectional)
+	}
+	a.Units = l.units(raw)
+	if len(a.Units) > 0 {
+		a.UnitDesignator = a.Units[len(a.Units)-1].Designator
+		a.Unit = a.Units[len(a.Units)-1].Value
+	}
+	a.City = join(labelCity)
+	a.State = StateAbbreviation(join(labelState))
+	a.PostalCode, a.PostalCodeExt, a.DeliveryPoint = splitZipcode(join(labelPostalCode))
+
+	a.Kind = l.kind
+	a.Route = join(labelRoute)
+	a.Box = join(labelBox)
+	a.MilitaryUnitType = join(labelMilitaryUnitType)
+	a.MilitaryUnit = join(labelMilitaryUnit)
+
+	if l.kind == KindPoBox {
+		a.Box = a.HouseNumber
+		a.StreetName = "PO BOX"
+		if raw {
+			a.StreetName = "PO Box"
+		}
+	}
+}
+
+// units collects the labeled secondary units in order.
+func (l *labeling) units(raw bool) (units []SecondaryUnit) {
+	for _, t := range l.tokens {
+		text := t.text
+		if raw {
+			text = t.raw
+		}
+
+		switch t.label {
+		case labelUnitDesignator:
+			units = append(units, SecondaryUnit{Designator: text})
+		case labelUnit:
+			if len(units) == 0 || units[len(units)-1].Value != "" {
+				units = append(units, SecondaryUnit{})
+			}
+			units[len(units)-1].Value = text
+		}
+	}
+
+	return
+}
+
+// check returns the first reason the labeled address isn't usable.
+func (l *labeling) check(a *Address, input string) error {
+	var err *ParseError
+	if l.kind.isRoute() && a.Box == "" {
+		err = newParseError(MissingHouseNumber, "", input)
+	} else if l.kind.hasStreet() && a.HouseNumber == "" {
+		var token string
+		if len(l.tokens) > 0 && l.kind == KindStreet {
+			token = l.tokens[0].text
+		}
+		err = newParseError(MissingHouseNumber, token, input)
+	} else if l.err != nil {
+		err = l.err
+		err.Input = input
+	} else if a.StreetName == "" && l.kind.hasStreet() {
+		err = newParseError(MissingStreetName, "", input)
+	} else if l.kind == KindMilitary && a.PostalCode != "" && isMilitaryState(a.State) && !isMilitaryZip(a.PostalCode, a.State) {
+		err = newParseError(InvalidZip, a.PostalCode, input)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,273 @@
+package godress
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MatchLevel is how much of two addresses points to the same place.
+type MatchLevel int
+
+const (
+	// MatchNone means the addresses are in different places.
+	MatchNone MatchLevel = iota
+	// MatchZip means the addresses share a zip code, or a city and state.
+	MatchZip
+	// MatchStreet means the addresses are on the same street, or the same
+	// route or military unit.
+	MatchStreet
+	// MatchBuilding means the addresses are the same building, or box,
+	// with different units.
+	MatchBuilding
+	// MatchExact means the addresses are the same place.
+	MatchExact
+)
+
+var matchLevelNames = map[MatchLevel]string{
+	MatchNone:     "none",
+	MatchZip:      "same zip code",
+	MatchStreet:   "same street",
+	MatchBuilding: "same building",
+	MatchExact:    "exact",
+}
+
+// String returns a human readable description of the level.
+func (l MatchLevel) String() string {
+	if name, ok := matchLevelNames[l]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("MatchLevel(%d)", int(l))
+}
+
+// FieldOutcome is how a field of two addresses compares.
+type FieldOutcome int
+
+const (
+	// FieldEqual means the field is the same once standardized.
+	FieldEqual FieldOutcome = iota + 1
+	// FieldSimilar means the field is a few edits away, like a typo.
+	FieldSimilar
+	// FieldMissing means only one of the addresses has the field.
+	FieldMissing
+	// FieldDifferent means the field is different.
+	FieldDifferent
+)
+
+var fieldOutcomeNames = map[FieldOutcome]string{
+	FieldEqual:     "equal",
+	FieldSimilar:   "similar",
+	FieldMissing:   "missing",
+	FieldDifferent: "different",
+}
+
+// String returns a human readable description of the outcome.
+func (o FieldOutcome) String() string {
+	if name, ok := fieldOutcomeNames[o]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("FieldOutcome(%d)", int(o))
+}
+
+// FieldMatch explains how a field of two addresses compares, with the
+// standardized values that were compared.
+type FieldMatch struct {
+	Field   Field        `json:"field"`
+	A       string       `json:"a"`
+	B       string       `json:"b"`
+	Outcome FieldOutcome `json:"outcome"`
+	Score   float64      `json:"score"`
+}
+
+// MatchResult is the outcome of comparing two addresses.
+type MatchResult struct {
+	Level  MatchLevel   `json:"level"`
+	Score  float64      `json:"score"`
+	Fields []FieldMatch `json:"fields"`
+}
+
+// matchWeights is how much each field counts towards the score of a match.
+var matchWeights = map[Field]float64{
+	FieldHouseNumber:      3,
+	FieldBox:              3,
+	FieldStreetName:       3,
+	FieldRoute:            3,
+	FieldMilitaryUnit:     3,
+	FieldPostalCode:       2,
+	FieldUnit:             2,
+	FieldPreDirectional:   1,
+	FieldStreetPreType:    1,
+	FieldStreetType:       1,
+	FieldPostDirectional:  1,
+	FieldMilitaryUnitType: 1,
+	FieldUrbanization:     1,
+	FieldCity:             1,
+	FieldState:            1,
+}
+
+// Match compares two addresses field by field, once standardized like
+// CanonicalKey, and returns how much of them points to the same place.
+// The score is the weighted share of the fields they agree on, a field
+// only one of them has counting half. A nil address matches nothing.
+func Match(a, b *Address) MatchResult {
+	var r MatchResult
+	if a == nil || b == nil {
+		return r
+	}
+
+	outcomes := map[Field]FieldOutcome{}
+	compare := func(field Field, x, y string, fuzzy bool) {
+		x, y = canonical(x), canonical(y)
+		if x == "" && y == "" {
+			return
+		}
+
+		m := FieldMatch{Field: field, A: x, B: y, Outcome: FieldDifferent}
+		switch {
+		case x == y:
+			m.Outcome, m.Score = FieldEqual, 1
+		case x == "" || y == "":
+			m.Outcome, m.Score = FieldMissing, 0.5
+		case fuzzy && similar(x, y):
+			m.Outcome, m.Score = FieldSimilar, 0.75
+		}
+
+		outcomes[field] = m.Outcome
+		r.Fields = append(r.Fields, m)
+	}
+
+	compare(FieldUrbanization, a.Urbanization, b.Urbanization, true)
+	switch {
+	case a.Kind == KindMilitary || b.Kind == KindMilitary:
+		compare(FieldMilitaryUnitType, a.MilitaryUnitType, b.MilitaryUnitType, false)
+		compare(FieldMilitaryUnit, a.MilitaryUnit, b.MilitaryUnit, false)
+		compare(FieldBox, a.Box, b.Box, false)
+	case a.Kind.isRoute() || b.Kind.isRoute():
+		compare(FieldRoute, a.Route, b.Route, false)
+		compare(FieldBox, a.Box, b.Box, false)
+	case a.Kind == KindPoBox || b.Kind == KindPoBox:
+		compare(FieldBox, a.Box, b.Box, false)
+	default:
+		compare(FieldHouseNumber, a.HouseNumber, b.HouseNumber, false)
+		compare(FieldPreDirectional, directionAbbr(a.PreDirectional), directionAbbr(b.PreDirectional), false)
+		compare(FieldStreetPreType, a.StreetPreType, b.StreetPreType, false)
+		compare(FieldStreetName, a.StreetName, b.StreetName, true)
+		compare(FieldStreetType, StreetTypeAbbr(a.StreetType), StreetTypeAbbr(b.StreetType), false)
+		compare(FieldPostDirectional, directionAbbr(a.PostDirectional), directionAbbr(b.PostDirectional), false)
+		compare(FieldUnit, canonicalUnits(a), canonicalUnits(b), false)
+	}
+
+	// Cities are compared by the zip code's primary name when both are
+	// names of it, so St George and Saint George are the same.
+	zipA, _, _ := splitZipcode(a.PostalCode)
+	zipB, _, _ := splitZipcode(b.PostalCode)
+	cityA, cityB := a.City, b.City
+	if info, ok := LookupZip(zipA); ok && zipA == zipB && zipHasCity(info, cityA) && zipHasCity(info, cityB) {
+		cityA, cityB = info.PrimaryCity, info.PrimaryCity
+	}
+	compare(FieldCity, cityA, cityB, true)
+	compare(FieldState, StateAbbreviation(a.State), StateAbbreviation(b.State), false)
+	compare(FieldPostalCode, zipA, zipB, false)
+
+	var total float64
+	for _, m := range r.Fields {
+		r.Score += m.Score * matchWeights[m.Field]
+		total += matchWeights[m.Field]
+	}
+	if total > 0 {
+		r.Score /= total
+	}
+
+	r.Level = matchLevel(a, b, outcomes)
+
+	return r
+}
+
+// Match compares the address to b like the package level Match.
+func (a *Address) Match(b *Address) MatchResult {
+	return Match(a, b)
+}
+
+// matchLevel finds the level of a match from the outcome of each field. A
+// field only one of the addresses has doesn't stand in the way of a level,
+// a similar one stands in the way of an exact match only.
+func matchLevel(a, b *Address, outcomes map[Field]FieldOutcome) MatchLevel {
+	agree := func(fields ...Field) bool {
+		for _, field := range fields {
+			if outcomes[field] == FieldDifferent || outcomes[field] == FieldSimilar {
+				return false
+			}
+		}
+
+		return true
+	}
+	near := func(fields ...Field) bool {
+		for _, field := range fields {
+			if outcomes[field] == FieldDifferent {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Without zip codes to go by, the city and state have to do.
+	if outcomes[FieldPostalCode] == FieldEqual {
+		if !agree(FieldState) {
+			return MatchNone
+		}
+	} else if !agree(FieldPostalCode, FieldState) || !near(FieldCity) {
+		return MatchNone
+	}
+
+	kindA, kindB := a.Kind, b.Kind
+	if kindA == "" {
+		kindA = KindStreet
+	}
+	if kindB == "" {
+		kindB = KindStreet
+	}
+
+	// The building of a PO box, route or military address is its box.
+	street, building := []Field{FieldUrbanization, FieldPreDirectional, FieldStreetPreType, FieldStreetName, FieldStreetType, FieldPostDirectional}, FieldHouseNumber
+	switch kindA {
+	case KindMilitary:
+		street, building = []Field{FieldMilitaryUnitType, FieldMilitaryUnit}, FieldBox
+	case KindRuralRoute, KindHighwayContract:
+		street, building = []Field{FieldRoute}, FieldBox
+	case KindPoBox:
+		street, building = nil, FieldBox
+	}
+
+	switch {
+	case kindA != kindB || !near(street...):
+		return MatchZip
+	case outcomes[building] != FieldEqual:
+		return MatchStreet
+	case outcomes[FieldUnit] == FieldDifferent || outcomes[FieldUnit] == FieldMissing:
+		return MatchBuilding
+	case !agree(street...) || !agree(FieldCity):
+		// A typo might be another place after all.
+		return MatchBuilding
+	}
+
+	return MatchExact
+}
+
+// similar reports whether a and b are within a typo of each other, word
+// by word.
+func similar(a, b string) bool {
+	wordsA, wordsB := strings.Fields(a), strings.Fields(b)
+	if len(wordsA) != len(wordsB) {
+		return false
+	}
+
+	for i := range wordsA {
+		if wordsA[i] != wordsB[i] && editDistance(wordsA[i], wordsB[i]) > maxEdits(wordsA[i]) {
+			return false
+		}
+	}
+
+	return true
+}
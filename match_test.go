@@ -0,0 +1,70 @@
+package godress
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := map[[2]string]MatchLevel{
+		{"123 North Center Street, Lehi, UT 84043", "123 N Center St Lehi UT 84043-1234"}: MatchExact,
+		{"123 Main St Apartment 4, Lehi, UT", "123 Main St Apt 4 Lehi Utah"}:              MatchExact,
+		{"123 Main St, St George, UT 84770", "123 Main St, Saint George, UT 84770"}:       MatchExact,
+		{"123 Main St, Lehi, UT 84043", "123 Main St Apt 4, Lehi, UT 84043"}:              MatchBuilding,
+		{"123 Main St Apt 4, Lehi, UT 84043", "123 Main St Apt 5, Lehi, UT 84043"}:        MatchBuilding,
+		{"123 Center St, Lehi, UT 84043", "123 Centre St, Lehi, UT 84043"}:                MatchBuilding,
+		{"123 Main St, Lehi, UT 84043", "125 Main St, Lehi, UT 84043"}:                    MatchStreet,
+		{"123 Main St, Lehi, UT 84043", "123 Main Ave, Lehi, UT 84043"}:                   MatchZip,
+		{"123 Main St, Lehi, UT 84043", "PO Box 123, Lehi, UT 84043"}:                     MatchZip,
+		{"123 Main St, Lehi, UT 84043", "123 Main St, Lehi, UT 84045"}:                    MatchNone,
+		{"123 Main St, Lehi, UT", "123 Main St, Provo, UT"}:                               MatchNone,
+		{"PO Box 12, Lehi, UT 84043", "P.O. Box 12 Lehi UT 84043"}:                        MatchExact,
+		{"PO Box 12, Lehi, UT 84043", "PO Box 13, Lehi, UT 84043"}:                        MatchStreet,
+		{"RR 2 Box 15, Lehi, UT 84043", "Rural Route 2 Box 16, Lehi, UT 84043"}:           MatchStreet,
+		{"PSC 1234 Box 5678 APO AE 09204", "PSC 1234 BOX 5678, APO, AE 09204"}:            MatchExact,
+	}
+
+	for addresses, expected := range tests {
+		a, b := MustParse(addresses[0]), MustParse(addresses[1])
+		if got := Match(a, b); got.Level != expected {
+			t.Errorf("%q and %q: expected %s, got %s %+v", addresses[0], addresses[1], expected, got.Level, got.Fields)
+		}
+		if got := b.Match(a); got.Level != expected {
+			t.Errorf("%q and %q: expected %s both ways, got %s", addresses[1], addresses[0], expected, got.Level)
+		}
+	}
+
+	exact := Match(MustParse("123 N Center St Lehi UT 84043"), MustParse("123 North Center Street Lehi UT 84043"))
+	if exact.Score != 1 {
+		t.Errorf("expected a score of 1, got %v", exact.Score)
+	}
+
+	r := Match(MustParse("123 Main St, Lehi, UT 84043"), MustParse("125 Main St Apt 4, Lehi, UT 84043"))
+	if r.Score <= 0 || r.Score >= 1 {
+		t.Errorf("expected a partial score, got %v", r.Score)
+	}
+
+	explained := map[Field]FieldOutcome{}
+	for _, m := range r.Fields {
+		explained[m.Field] = m.Outcome
+	}
+	expected := map[Field]FieldOutcome{
+		FieldHouseNumber: FieldDifferent,
+		FieldStreetName:  FieldEqual,
+		FieldStreetType:  FieldEqual,
+		FieldUnit:        FieldMissing,
+		FieldCity:        FieldEqual,
+		FieldState:       FieldEqual,
+		FieldPostalCode:  FieldEqual,
+	}
+	for field, outcome := range expected {
+		if explained[field] != outcome {
+			t.Errorf("%s: expected %s, got %s", field, outcome, explained[field])
+		}
+	}
+
+	a := MustParse("123 Main St, Lehi, UT 84043")
+	if r := Match(nil, a); r.Level != MatchNone || r.Score != 0 || r.Fields != nil {
+		t.Errorf("expected a nil address to match nothing, got %+v", r)
+	}
+	if r := a.Match(nil); r.Level != MatchNone {
+		t.Errorf("expected a nil address to match nothing, got %+v", r)
+	}
+}
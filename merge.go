@@ -0,0 +1,345 @@
+package godress
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// refinementRegex matches what makes a number more specific: a letter, as
+// in 4B or 123-A, or a fraction, as in 123 1/2.
+var refinementRegex = regexp.MustCompile(`^(-?[A-Z]| \d+/\d+)$`)
+
+// MergePolicy decides which value Merge keeps when two addresses disagree
+// on a field. Blank fields are filled in whatever the policy.
+type MergePolicy int
+
+const (
+	// MergePreferNonEmpty keeps the address' own values, only filling in
+	// the ones it is missing.
+	MergePreferNonEmpty MergePolicy = iota + 1
+	// MergePreferMoreSpecific keeps the value that says more, like APT 4B
+	// over APT 4, or else the value of the more complete address.
+	MergePreferMoreSpecific
+	// MergePreferValidated keeps the values of the address with fewer
+	// validation issues, like a zip code that agrees with its state.
+	MergePreferValidated
+)
+
+var mergePolicyNames = map[MergePolicy]string{
+	MergePreferNonEmpty:     "prefer non-empty",
+	MergePreferMoreSpecific: "prefer more specific",
+	MergePreferValidated:    "prefer validated",
+}
+
+// String returns a human readable description of the policy.
+func (p MergePolicy) String() string {
+	if name, ok := mergePolicyNames[p]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("MergePolicy(%d)", int(p))
+}
+
+// Conflict is a field two merged addresses disagree on, with the value
+// the address kept and the one it dropped.
+type Conflict struct {
+	Field   Field  `json:"field"`
+	Kept    string `json:"kept"`
+	Dropped string `json:"dropped"`
+}
+
+// mergeField is a field Merge goes through. key is its standardized
+// value, which tells whether two addresses agree, and set copies it, along
+// with the fields that go with it, from one address to another. A field
+// with an anchor is taken along with its anchor, so the street type of one
+// street isn't put on the name of another.
+type mergeField struct {
+	field  Field
+	anchor Field
+	key    func(a *Address) string
+	value  func(a *Address) string
+	set    func(dst, src *Address)
+}
+
+var mergeFields = []mergeField{
+	{
+		field: FieldKind,
+		key:   func(a *Address) string { return string(kindOf(a)) },
+		value: func(a *Address) string { return string(kindOf(a)) },
+		set:   func(dst, src *Address) { dst.Kind = src.Kind },
+	},
+	{
+		field:  FieldHouseNumber,
+		anchor: FieldKind,
+		key:    func(a *Address) string { return canonical(a.HouseNumber) },
+		value:  func(a *Address) string { return a.HouseNumber },
+		set: func(dst, src *Address) {
+			dst.HouseNumber, dst.HouseNumberBase, dst.HouseNumberSuffix = src.HouseNumber, src.HouseNumberBase, src.HouseNumberSuffix
+			dst.HouseNumberFraction, dst.HouseNumberRangeEnd = src.HouseNumberFraction, src.HouseNumberRangeEnd
+		},
+	},
+	{
+		field:  FieldStreetName,
+		anchor: FieldKind,
+		key:    func(a *Address) string { return canonical(a.StreetName) },
+		value:  func(a *Address) string { return a.StreetName },
+		set: func(dst, src *Address) {
+			dst.StreetName, dst.GridCoordinate, dst.GridDirection = src.StreetName, src.GridCoordinate, src.GridDirection
+		},
+	},
+	{
+		field:  FieldUrbanization,
+		anchor: FieldStreetName,
+		key:    func(a *Address) string { return canonical(a.Urbanization) },
+		value:  func(a *Address) string { return a.Urbanization },
+		set:    func(dst, src *Address) { dst.Urbanization = src.Urbanization },
+	},
+	{
+		field:  FieldPreDirectional,
+		anchor: FieldStreetName,
+		key:    func(a *Address) string { return canonical(directionAbbr(a.PreDirectional)) },
+		value:  func(a *Address) string { return a.PreDirectional },
+		set:    func(dst, src *Address) { dst.PreDirectional = src.PreDirectional },
+	},
+	{
+		field:  FieldStreetPreType,
+		anchor: FieldStreetName,
+		key:    func(a *Address) string { return canonical(a.StreetPreType) },
+		value:  func(a *Address) string { return a.StreetPreType },
+		set:    func(dst, src *Address) { dst.StreetPreType = src.StreetPreType },
+	},
+	{
+		field:  FieldStreetType,
+		anchor: FieldStreetName,
+		key:    func(a *Address) string { return canonical(StreetTypeAbbr(a.StreetType)) },
+		value:  func(a *Address) string { return a.StreetType },
+		set:    func(dst, src *Address) { dst.StreetType = src.StreetType },
+	},
+	{
+		field:  FieldPostDirectional,
+		anchor: FieldStreetName,
+		key:    func(a *Address) string { return canonical(directionAbbr(a.PostDirectional)) },
+		value:  func(a *Address) string { return a.PostDirectional },
+		set:    func(dst, src *Address) { dst.PostDirectional = src.PostDirectional },
+	},
+	{
+		field:  FieldUnit,
+		anchor: FieldHouseNumber,
+		key:    canonicalUnits,
+		value:  func(a *Address) string { return strings.TrimSpace(a.UnitDesignator + " " + a.Unit) },
+		set: func(dst, src *Address) {
+			dst.UnitDesignator, dst.Unit = src.UnitDesignator, src.Unit
+			dst.Units = append([]SecondaryUnit(nil), src.Units...)
+		},
+	},
+	{
+		field:  FieldRoute,
+		anchor: FieldKind,
+		key:    func(a *Address) string { return canonical(a.Route) },
+		value:  func(a *Address) string { return a.Route },
+		set:    func(dst, src *Address) { dst.Route = src.Route },
+	},
+	{
+		field:  FieldMilitaryUnitType,
+		anchor: FieldKind,
+		key:    func(a *Address) string { return canonical(a.MilitaryUnitType) },
+		value:  func(a *Address) string { return a.MilitaryUnitType },
+		set:    func(dst, src *Address) { dst.MilitaryUnitType = src.MilitaryUnitType },
+	},
+	{
+		field:  FieldMilitaryUnit,
+		anchor: FieldMilitaryUnitType,
+		key:    func(a *Address) string { return canonical(a.MilitaryUnit) },
+		value:  func(a *Address) string { return a.MilitaryUnit },
+		set:    func(dst, src *Address) { dst.MilitaryUnit = src.MilitaryUnit },
+	},
+	{
+		field:  FieldBox,
+		anchor: FieldKind,
+		key:    func(a *Address) string { return canonical(a.Box) },
+		value:  func(a *Address) string { return a.Box },
+		set:    func(dst, src *Address) { dst.Box = src.Box },
+	},
+	{
+		field: FieldCity,
+		key:   func(a *Address) string { return canonical(a.City) },
+		value: func(a *Address) string { return a.City },
+		set:   func(dst, src *Address) { dst.City = src.City },
+	},
+	{
+		field: FieldState,
+		key:   func(a *Address) string { return canonical(StateAbbreviation(a.State)) },
+		value: func(a *Address) string { return a.State },
+		set:   func(dst, src *Address) { dst.State = src.State },
+	},
+	{
+		field: FieldPostalCode,
+		key: func(a *Address) string {
+			zip, _, _ := splitZipcode(a.PostalCode)
+			return zip
+		},
+		value: func(a *Address) string { return a.PostalCode },
+		set: func(dst, src *Address) {
+			dst.PostalCode, dst.PostalCodeExt, dst.DeliveryPoint = src.PostalCode, src.PostalCodeExt, src.DeliveryPoint
+		},
+	},
+	{
+		field:  FieldCounty,
+		anchor: FieldPostalCode,
+		key:    func(a *Address) string { return canonical(a.County) },
+		value:  func(a *Address) string { return a.County },
+		set: func(dst, src *Address) {
+			dst.County, dst.Latitude, dst.Longitude = src.County, src.Latitude, src.Longitude
+		},
+	},
+	{
+		field: FieldCountry,
+		key:   func(a *Address) string { return canonical(a.Country) },
+		value: func(a *Address) string { return a.Country },
+		set:   func(dst, src *Address) { dst.Country = src.Country },
+	},
+}
+
+// mergeOutcome is what Merge did with a field.
+type mergeOutcome int
+
+const (
+	mergeAgreed mergeOutcome = iota
+	mergeFilled
+	mergeKept
+	mergeTaken
+)
+
+// Merge fills in the blank fields of the address from other and returns
+// the fields they disagree on. The policy decides which value each
+// conflicting field keeps. Fields that go together, like a street name and
+// its type, or a house number and its unit, are kept or taken together.
+// Merge doesn't check that the addresses are the same place, so compare
+// them with Match first when that isn't known, i.e. only merging at
+// MatchBuilding or above. Merge complements SetStreet, which replaces the
+// street of an address outright.
+func (a *Address) Merge(other *Address, policy MergePolicy) (conflicts []Conflict) {
+	if other == nil {
+		return nil
+	}
+
+	preferOther := mergePreference(a, other, policy)
+	outcomes := map[Field]mergeOutcome{}
+	for _, f := range mergeFields {
+		x, y := f.key(a), f.key(other)
+
+		switch outcomes[f.anchor] {
+		case mergeKept:
+			outcomes[f.field] = mergeKept
+			continue
+		case mergeTaken:
+			f.set(a, other)
+			outcomes[f.field] = mergeTaken
+			continue
+		}
+
+		switch {
+		case x == y:
+			outcomes[f.field] = mergeAgreed
+			if f.field == FieldPostalCode && a.PostalCodeExt == "" {
+				a.PostalCodeExt, a.DeliveryPoint = other.PostalCodeExt, other.DeliveryPoint
+			}
+		case y == "":
+			outcomes[f.field] = mergeAgreed
+		case x == "":
+			f.set(a, other)
+			outcomes[f.field] = mergeFilled
+			if other.IsInferred(f.field) {
+				a.infer(f.field)
+			}
+		default:
+			conflict := Conflict{Field: f.field, Kept: f.value(a), Dropped: f.value(other)}
+			outcomes[f.field] = mergeKept
+			if preferOther(x, y) {
+				f.set(a, other)
+				conflict.Kept, conflict.Dropped = conflict.Dropped, conflict.Kept
+				outcomes[f.field] = mergeTaken
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	a.StreetDirection = a.PreDirectional
+	if a.StreetDirection == "" {
+		a.StreetDirection = a.PostDirectional
+	}
+	if a.Hash != "" {
+		a.Hash = HashAddress(a, hashAlgorithmOf(a.Hash))
+	}
+
+	return conflicts
+}
+
+// mergePreference returns how a policy picks between two conflicting
+// values, reporting whether the value of other wins over the value of a.
+func mergePreference(a, other *Address, policy MergePolicy) func(x, y string) bool {
+	switch policy {
+	case MergePreferMoreSpecific:
+		moreComplete := completeness(other) > completeness(a)
+		return func(x, y string) bool {
+			switch {
+			case extends(y, x):
+				return true
+			case extends(x, y):
+				return false
+			}
+
+			return moreComplete
+		}
+	case MergePreferValidated:
+		better := validationRank(Validate(other)) < validationRank(Validate(a))
+		return func(x, y string) bool { return better }
+	}
+
+	return func(x, y string) bool { return false }
+}
+
+// extends reports whether a says all b does and more, adding a letter or a
+// fraction after the same number, like APT 4B does APT 4 or 123 1/2 does
+// 123. A longer number, like 1234 or APT 45, is another place.
+func extends(a, b string) bool {
+	if b == "" || !strings.HasPrefix(a, b) || !isDigits(b[len(b)-1:]) {
+		return false
+	}
+
+	return refinementRegex.MatchString(a[len(b):])
+}
+
+// validationRank ranks validation issues, fewer errors first, then fewer
+// warnings.
+func validationRank(issues []Issue) int {
+	rank := 0
+	for _, issue := range issues {
+		switch issue.Severity {
+		case SeverityError:
+			rank += 100
+		case SeverityWarning:
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// hashAlgorithmOf returns the algorithm a hash was made with.
+func hashAlgorithmOf(hash string) HashAlgorithm {
+	if parts := strings.SplitN(hash, ":", 3); len(parts) == 3 {
+		return HashAlgorithm(parts[1])
+	}
+
+	return HashSHA256
+}
+
+func kindOf(a *Address) AddressKind {
+	if a.Kind == "" && a.StreetName != "" {
+		return KindStreet
+	}
+
+	return a.Kind
+}
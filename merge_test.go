@@ -0,0 +1,73 @@
+package godress
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	a := MustParse("123 Main St 84043")
+	conflicts := a.Merge(MustParse("123 Main St Apt 4, Lehi UT"), MergePreferNonEmpty)
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %v", conflicts)
+	}
+	if a.String() != "123 MAIN ST APT 4 LEHI, UT 84043" || a.Units[0].Designator != "APT" {
+		t.Errorf("expected the blanks to be filled in, got %s", a)
+	}
+	if a.Hash != MustParse("123 Main St Apt 4, Lehi UT 84043").Hash {
+		t.Errorf("expected the hash of the merged address, got %s", a.Hash)
+	}
+
+	tests := map[MergePolicy][2]string{
+		MergePreferNonEmpty:     {"123 MAIN ST APT 4 LEHI, CA 84043", "[{unit APT 4 APT 4B} {state CA UT} {postal_code 84043 84045}]"},
+		MergePreferMoreSpecific: {"123 MAIN ST APT 4B LEHI, CA 84043", "[{unit APT 4B APT 4} {state CA UT} {postal_code 84043 84045}]"},
+		MergePreferValidated:    {"123 MAIN ST APT 4B LEHI, UT 84045", "[{unit APT 4B APT 4} {state UT CA} {postal_code 84045 84043}]"},
+	}
+	for policy, expected := range tests {
+		a := MustParse("123 Main St Apt 4, Lehi, CA 84043")
+		conflicts := a.Merge(MustParse("123 Main St Apt 4B, Lehi, UT 84045"), policy)
+		if got := a.String(); got != expected[0] {
+			t.Errorf("%s: expected %s, got %s", policy, expected[0], got)
+		}
+		if got := fmt.Sprint(conflicts); got != expected[1] {
+			t.Errorf("%s: expected conflicts %s, got %s", policy, expected[1], got)
+		}
+	}
+
+	specific := map[[2]string]string{
+		{"123 Main St, Lehi, UT 84043", "1234 Main St, Lehi, UT 84043"}:             "[{house_number 123 1234}]",
+		{"123 Main St Apt 4, Lehi, UT 84043", "123 Main St Apt 45, Lehi, UT 84043"}: "[{unit APT 4 APT 45}]",
+		{"123 Main St, Lehi, UT 84043", "123 1/2 Main St, Lehi, UT 84043"}:          "[{house_number 123 1/2 123}]",
+		{"123 Main St Apt 4, Lehi, UT 84043", "123 Main St Apt 4B, Lehi, UT 84043"}: "[{unit APT 4B APT 4}]",
+	}
+	for pair, expected := range specific {
+		a := MustParse(pair[0])
+		if got := fmt.Sprint(a.Merge(MustParse(pair[1]), MergePreferMoreSpecific)); got != expected {
+			t.Errorf("%s and %s: expected conflicts %s, got %s", pair[0], pair[1], expected, got)
+		}
+	}
+
+	// The street type of another street isn't put on the name.
+	b := MustParse("123 Main, Lehi, UT 84043")
+	conflicts = b.Merge(MustParse("123 Center St, Lehi, UT 84043"), MergePreferNonEmpty)
+	if b.StreetName != "MAIN" || b.StreetType != "" {
+		t.Errorf("expected the street to be kept whole, got %s", b)
+	}
+	if expected := []Conflict{{FieldStreetName, "MAIN", "CENTER"}}; !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("expected %v, got %v", expected, conflicts)
+	}
+
+	c := MustParse("123 Main St, Lehi, UT 84043")
+	c.Merge(MustParse("123 Main St, Lehi, UT 84043-1234"), MergePreferNonEmpty)
+	if c.PostalCodeExt != "1234" {
+		t.Errorf("expected the +4 add-on to be filled in, got %q", c.PostalCodeExt)
+	}
+
+	d := &Address{HouseNumber: "123", StreetName: "Main"}
+	e, _ := NewParser(WithCompletion()).Parse("123 Main 84043")
+	d.Merge(e, MergePreferNonEmpty)
+	if d.City != "LEHI" || !d.IsInferred(FieldCity) {
+		t.Errorf("expected an inferred city to stay inferred, got %q %v", d.City, d.Inferred)
+	}
+}
@@ -0,0 +1,123 @@
+package godress
+
+import "strings"
+
+// KindMilitary is an APO, FPO or DPO address of a service member.
+const KindMilitary AddressKind = "military"
+
+var (
+	// militaryUnitTypes are the words a military address starts with, PSC
+	// and CMR for postal service centers and mail rooms, UNIT for units and
+	// USS or USNS for ships, which are followed by the ship's name.
+	militaryUnitTypes = map[string]bool{
+		"PSC":  true,
+		"CMR":  true,
+		"UNIT": true,
+		"USS":  true,
+		"USNS": true,
+	}
+
+	// militaryPostOffices take the place of the city in a military address.
+	militaryPostOffices = map[string]bool{
+		"APO": true,
+		"FPO": true,
+		"DPO": true,
+	}
+
+	// militaryZipPrefixes are the zip code prefixes of each armed forces
+	// state, 090 through 098 for Europe, 340 for the Americas and 962
+	// through 966 for the Pacific.
+	militaryZipPrefixes = map[string][]string{
+		"AE": {"090", "091", "092", "093", "094", "095", "096", "097", "098"},
+		"AA": {"340"},
+		"AP": {"962", "963", "964", "965", "966"},
+	}
+)
+
+// isMilitaryState reports whether s is one of the armed forces states.
+func isMilitaryState(s string) bool {
+	return StateKindOf(s) == StateKindMilitary
+}
+
+// isMilitaryZip reports whether zip is within the range of the armed
+// forces state.
+func isMilitaryZip(zip, state string) bool {
+	for _, prefix := range militaryZipPrefixes[strings.ToUpper(state)] {
+		if strings.HasPrefix(zip, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// militaryAt returns how many tokens at the start of tokens spell the unit
+// type of a military address, or 0 when they don't. UNIT is also a
+// secondary unit, so it needs an APO, FPO or DPO later on.
+func militaryAt(tokens []*token) int {
+	if len(tokens) < 2 || !militaryUnitTypes[tokens[0].text] {
+		return 0
+	}
+
+	switch tokens[0].text {
+	case "USS", "USNS":
+		return 1
+	case "UNIT":
+		for _, t := range tokens[2:] {
+			if militaryPostOffices[t.text] {
+				return 1
+			}
+		}
+
+		return 0
+	}
+
+	if !isUnitValue(tokens[1].text) {
+		return 0
+	}
+
+	return 1
+}
+
+// labelMilitary labels the unit type, unit and box of a military address,
+// returning the index of the first token after them.
+func (l *labeling) labelMilitary() int {
+	l.tokens[0].set(labelMilitaryUnitType, ruleDictionary)
+
+	i := 1
+	ship := l.tokens[0].text == "USS" || l.tokens[0].text == "USNS"
+	for ; i < len(l.tokens); i++ {
+		t := l.tokens[i]
+		if militaryPostOffices[t.text] || isBoxKeyword(t.text) || (!ship && i > 1) {
+			break
+		}
+
+		t.set(labelMilitaryUnit, ruleKeyword)
+		if t.comma {
+			i++
+			break
+		}
+	}
+
+	if i+1 < len(l.tokens) && isBoxKeyword(l.tokens[i].text) && isUnitValue(l.tokens[i+1].text) {
+		l.tokens[i].set(labelIgnored, ruleDictionary)
+		l.tokens[i+1].set(labelBox, ruleKeyword)
+		i += 2
+	}
+
+	return i
+}
+
+// formatMilitary formats the unit and box of a military address the way
+// Publication 28 writes it, i.e. PSC 1234 BOX 5678.
+func formatMilitary(unitType, unit, box string) string {
+	s := unitType
+	if unit != "" {
+		s += " " + unit
+	}
+	if box != "" {
+		s += " BOX " + box
+	}
+
+	return s
+}
@@ -0,0 +1,35 @@
+package godress
+
+import "testing"
+
+func TestParseMilitary(t *testing.T) {
+	tests := map[string][5]string{
+		"PSC 1234 Box 5678 APO AE 09204":    {"PSC", "1234", "5678", "APO", "PSC 1234 BOX 5678 APO AE 09204"},
+		"Unit 2050 Box 4190 APO AP 96278":   {"UNIT", "2050", "4190", "APO", "UNIT 2050 BOX 4190 APO AP 96278"},
+		"CMR 450 Box 1, APO, AE 09058":      {"CMR", "450", "1", "APO", "CMR 450 BOX 1 APO AE 09058"},
+		"Unit 2050 Box 4190, DPO, AA 34020": {"UNIT", "2050", "4190", "DPO", "UNIT 2050 BOX 4190 DPO AA 34020"},
+		"USS Ronald Reagan FPO AP 96616":    {"USS", "RONALD REAGAN", "", "FPO", "USS RONALD REAGAN FPO AP 96616"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		} else if a.Kind != KindMilitary {
+			t.Errorf("%s: expected a military address, got %s", s, a.Kind)
+		}
+		got := [5]string{a.MilitaryUnitType, a.MilitaryUnit, a.Box, a.City, a.String()}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	if _, err := Parse("PSC 1234 Box 5678 APO AE 84043"); err == nil || err.(*ParseError).Kind != InvalidZip {
+		t.Errorf("expected a zip code outside of AE to be invalid, got %v", err)
+	}
+
+	if a, _ := Parse("123 Main St Unit 5, Lehi, UT"); a.Kind != KindStreet || a.Unit != "5" {
+		t.Errorf("expected a unit on a street address not to be military, got %s", a.Kind)
+	}
+}
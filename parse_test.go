@@ -3,6 +3,7 @@ package godress
 import (
 	"encoding/json"
 	"log"
+	"reflect"
 	"testing"
 
 	"github.com/fatih/color"
@@ -10,9 +11,12 @@ import (
 
 func TestParse(t *testing.T) {
 	address1 := &Address{
+		Kind:            KindStreet,
 		Original:        "123 N CENTER ST LEHI, UT 84043",
-		Hash:            "aa7118672d057b7ff9ec7fedb1abc850",
+		Hash:            "v1:sha256:31bc173a87c0c7256a0e83d77b3f45bbbf8cf928699bd608361666ff68e19464",
 		HouseNumber:     "123",
+		HouseNumberBase: "123",
+		PreDirectional:  "N",
 		StreetDirection: "N",
 		StreetName:      "CENTER",
 		StreetType:      "ST",
@@ -22,20 +26,29 @@ func TestParse(t *testing.T) {
 	}
 
 	address2 := &Address{
+		Kind:            KindStreet,
 		Original:        "137 N 800 E SPANISH FORK, UT 84660",
-		Hash:            "f42142554958f74799bdb1e992a0a60e",
+		Hash:            "v1:sha256:c9865983edd2f57cab8f9caa97a23a4fd82918ca20fb172c7328ee1ca088ef8a",
 		HouseNumber:     "137",
+		HouseNumberBase: "137",
+		PreDirectional:  "N",
 		StreetDirection: "N",
-		StreetName:      "800 E",
+		StreetName:      "800",
+		GridCoordinate:  "800",
+		GridDirection:   "E",
+		PostDirectional: "E",
 		City:            "SPANISH FORK",
 		State:           "UT",
 		PostalCode:      "84660",
 	}
 
 	address3 := &Address{
+		Kind:            KindStreet,
 		Original:        "2505 NE 135TH ST, SEATTLE, WA 98125",
-		Hash:            "7fa506f8000b944bf69dcfab008c6604",
+		Hash:            "v1:sha256:99dc807fe37e343350a9ab3e18af5065431470ca7ab434e4c421af9a520e0990",
 		HouseNumber:     "2505",
+		HouseNumberBase: "2505",
+		PreDirectional:  "NE",
 		StreetDirection: "NE",
 		StreetName:      "135TH",
 		StreetType:      "ST",
@@ -45,9 +58,12 @@ func TestParse(t *testing.T) {
 	}
 
 	address4 := &Address{
+		Kind:            KindPoBox,
 		Original:        "PO BOX 523029 WEST CHESTER, PA 18630",
-		Hash:            "886b9d089a09b109643a477eb84e9ac7",
+		Hash:            "v1:sha256:5547e92f705fe0045d2b856da76c0ced5b92696acb8e1a60b4733b689879e415",
 		HouseNumber:     "523029",
+		Box:             "523029",
+		HouseNumberBase: "523029",
 		StreetDirection: "",
 		StreetName:      "PO BOX",
 		StreetType:      "",
@@ -67,12 +83,147 @@ func TestParse(t *testing.T) {
 	for s, a := range tests {
 		if pa, err := Parse(s); err != nil {
 			t.Errorf("error testing %s: %v", s, err)
-		} else if *pa != *a {
+		} else if !reflect.DeepEqual(pa, a) {
 			prettyPrint(t, a, pa)
 		}
 	}
 }
 
+func TestParseErrors(t *testing.T) {
+	tests := map[string]ParseErrorKind{
+		"hello world":                        MissingHouseNumber,
+		"123":                                MissingStreetName,
+		"123 Main St Lehi, XX 84043":         UnknownState,
+		"123 Main St Lehi, UT 00000":         InvalidZip,
+		"PO BOX West Chester, PA 18630":      MissingHouseNumber,
+		"PO BOX 523029 West Chester, PA 123": InvalidZip,
+	}
+
+	for s, kind := range tests {
+		_, err := Parse(s)
+		if perr, ok := err.(*ParseError); !ok {
+			t.Errorf("%s: expected *ParseError, got %v", s, err)
+		} else if perr.Kind != kind {
+			t.Errorf("%s: expected %s, got %s", s, kind, perr.Kind)
+		}
+	}
+
+	if _, err := Parse("123 Main St 84043"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseStreetErrors(t *testing.T) {
+	tests := map[string]ParseErrorKind{
+		"Main St": MissingHouseNumber,
+		"123":     MissingStreetName,
+		"PO Box":  MissingHouseNumber,
+	}
+
+	for s, kind := range tests {
+		_, err := ParseStreet(s)
+		if perr, ok := err.(*ParseError); !ok {
+			t.Errorf("%s: expected *ParseError, got %v", s, err)
+		} else if perr.Kind != kind {
+			t.Errorf("%s: expected %s, got %s", s, kind, perr.Kind)
+		}
+	}
+}
+
+func TestParseZipPlus4(t *testing.T) {
+	tests := map[string][3]string{
+		"123 Main St Lehi, UT 84043":         {"84043", "", ""},
+		"123 Main St Lehi, UT 84043-1234":    {"84043", "1234", ""},
+		"123 Main St Lehi, UT 84043 1234":    {"84043", "1234", ""},
+		"123 Main St Lehi, UT 840431234":     {"84043", "1234", ""},
+		"123 Main St Lehi, UT 84043123401":   {"84043", "1234", "01"},
+		"123 Main St Lehi, UT 84043-1234-01": {"84043", "1234", "01"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if got := [3]string{a.PostalCode, a.PostalCodeExt, a.DeliveryPoint}; got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+		if expected[1] != "" && a.String() != "123 MAIN ST LEHI, UT 84043-1234" {
+			t.Errorf("%s: expected ZIP+4 in %q", s, a.String())
+		}
+	}
+
+	for _, s := range []string{"840431234", "84043-1234", "84043 1234 01"} {
+		if !IsZipcode(s) {
+			t.Errorf("expected %s to be a zip code", s)
+		}
+	}
+	for _, s := range []string{"8404312", "84043-12", "84043 1234 5", "84043-1234-01-2"} {
+		if IsZipcode(s) {
+			t.Errorf("expected %s not to be a zip code", s)
+		}
+	}
+}
+
+func TestParseWithConfidence(t *testing.T) {
+	good, err := ParseWithConfidence("123 N Center St Lehi, UT 84043")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []Field{FieldHouseNumber, FieldPreDirectional, FieldStreetName, FieldStreetType, FieldCity, FieldState, FieldPostalCode} {
+		if c, ok := good.Confidence[field]; !ok || c <= 0 || c > 1 {
+			t.Errorf("%s: expected confidence in (0, 1], got %v", field, c)
+		}
+	}
+
+	if _, ok := good.Confidence[FieldUnit]; ok {
+		t.Errorf("expected no confidence for an empty unit")
+	}
+
+	typeless, _ := ParseWithConfidence("123 Center Lehi")
+	if typeless.Confidence[FieldStreetName] >= good.Confidence[FieldStreetName] {
+		t.Errorf("expected a street name without a type to be less certain, got %v >= %v", typeless.Confidence[FieldStreetName], good.Confidence[FieldStreetName])
+	}
+
+	bad, _ := ParseWithConfidence("hello world")
+	if bad.Score >= good.Score {
+		t.Errorf("expected a failed parse to score lower, got %v >= %v", bad.Score, good.Score)
+	}
+}
+
+func TestParseCandidates(t *testing.T) {
+	results, err := ParseCandidates("100 Park Ave Fork UT", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 candidates, got %d", len(results))
+	}
+
+	if a := results[0].Address; a.StreetName != "PARK" || a.StreetType != "AVE" || a.City != "FORK" {
+		t.Errorf("expected PARK AVE in FORK first, got %q %q in %q", a.StreetName, a.StreetType, a.City)
+	}
+
+	found := false
+	for i, r := range results {
+		if i > 0 && r.Score > results[i-1].Score {
+			t.Errorf("expected candidates ordered by score, got %v after %v", r.Score, results[i-1].Score)
+		}
+		found = found || (r.Address.StreetName == "PARK AVE" && r.Address.StreetType == "FORK")
+	}
+
+	if !found {
+		t.Errorf("expected PARK AVE FORK as an alternative")
+	}
+
+	if _, err := ParseCandidates("hello world", 3); err == nil {
+		t.Errorf("expected an error when there are no usable candidates")
+	}
+}
+
 func prettyPrint(t *testing.T, testInput, testOutput *Address) {
 	var (
 		expectedData, gotData []byte
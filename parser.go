@@ -0,0 +1,313 @@
+package godress
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	defaultParser       = NewParser()
+	defaultStreetParser = NewParser(WithOriginalCase())
+)
+
+// Parser parses addresses using its own dictionaries and settings. The
+// package level functions use a Parser with the default options.
+type Parser struct {
+	streetTypes     map[string]*streetSuffix
+	unitDesignators map[string]*Term
+	defaultState    string
+	defaultCountry  string
+	strict          bool
+	originalCase    bool
+	enrich          bool
+	complete        bool
+	lenient         bool
+	hyphenRanges    bool
+	hashAlgorithm   HashAlgorithm
+}
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// NewParser returns a parser configured by opts.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
+		streetTypes:     map[string]*streetSuffix{},
+		unitDesignators: map[string]*Term{},
+		hashAlgorithm:   HashSHA256,
+	}
+
+	for alias, suffix := range streetTypesByAlias {
+		p.streetTypes[alias] = suffix
+	}
+	for alias, term := range unitTerms {
+		p.unitDesignators[alias] = term
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithStreetType adds a street type, i.e. "Circle" abbreviated "Cir", along
+// with any other spellings of it.
+func WithStreetType(full, abbr string, aliases ...string) Option {
+	return func(p *Parser) {
+		suffix := &streetSuffix{primary: strings.ToUpper(full), abbr: strings.ToUpper(abbr)}
+		for _, alias := range aliases {
+			suffix.aliases = append(suffix.aliases, strings.ToUpper(alias))
+		}
+
+		for alias, suffix := range indexStreetSuffixes([]*streetSuffix{suffix}) {
+			p.streetTypes[alias] = suffix
+		}
+	}
+}
+
+// WithUnitDesignator adds a word that comes before a unit number,
+// i.e. "Ste" for "Suite".
+func WithUnitDesignator(abbr, label string) Option {
+	return func(p *Parser) {
+		for alias, term := range indexUnitTerms([]*Term{{abbr, label, false}}) {
+			p.unitDesignators[alias] = term
+		}
+	}
+}
+
+// WithDefaultState sets the state of addresses that don't have one.
+func WithDefaultState(state string) Option {
+	return func(p *Parser) {
+		p.defaultState = StateAbbreviation(state)
+	}
+}
+
+// WithDefaultCountry sets the country of addresses that don't have one.
+func WithDefaultCountry(country string) Option {
+	return func(p *Parser) {
+		p.defaultCountry = strings.ToUpper(country)
+	}
+}
+
+// WithStrict makes Parse fail on addresses missing a city, state or zip code.
+func WithStrict() Option {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// WithEnrichment makes Parse fill in the county and coordinates of
+// addresses from their zip code, and their city and state when missing.
+func WithEnrichment() Option {
+	return func(p *Parser) {
+		p.enrich = true
+	}
+}
+
+// WithCompletion makes Parse fill in the city and state of addresses from
+// their zip code, or their zip code from their city and state, like Complete.
+func WithCompletion() Option {
+	return func(p *Parser) {
+		p.complete = true
+	}
+}
+
+// WithLenient makes Parse correct misspelled street types, directions,
+// units, states and cities before labeling them, recording each
+// correction in the address' Corrections.
+func WithLenient() Option {
+	return func(p *Parser) {
+		p.lenient = true
+	}
+}
+
+// WithHyphenatedRanges makes Parse read a hyphenated house number as a
+// range when the second number is larger and as long as the first, like
+// 100-110, instead of as a single number, like 37-40 in Queens.
+func WithHyphenatedRanges() Option {
+	return func(p *Parser) {
+		p.hyphenRanges = true
+	}
+}
+
+// WithHashAlgorithm sets the algorithm the Hash of addresses is made with.
+func WithHashAlgorithm(algorithm HashAlgorithm) Option {
+	return func(p *Parser) {
+		p.hashAlgorithm = algorithm
+	}
+}
+
+// WithOriginalCase keeps the casing of the input instead of uppercasing it.
+func WithOriginalCase() Option {
+	return func(p *Parser) {
+		p.originalCase = true
+	}
+}
+
+// Parse parses a string into an address struct. When the input can't be
+// parsed a *ParseError is returned along with whatever parts were found.
+func (p *Parser) Parse(address string) (a *Address, err error) {
+	_, a, err = p.parse(address)
+
+	return
+}
+
+// ParseStreet atempts to parse a string into the parts of a street.
+// A *ParseError is returned along with the parts that were found when
+// the house number or street name is missing.
+func (p *Parser) ParseStreet(street string) (s *Street, err error) {
+	l := p.label(tokenize(street), true, labelOptions{})
+
+	a := &Address{}
+	l.assemble(a, p.originalCase)
+
+	s = &Street{
+		Kind:             a.Kind,
+		Urbanization:     a.Urbanization,
+		HouseNumber:      a.HouseNumber,
+		PreDirectional:   a.PreDirectional,
+		StreetDirection:  a.StreetDirection,
+		StreetPreType:    a.StreetPreType,
+		StreetName:       a.StreetName,
+		StreetType:       a.StreetType,
+		PostDirectional:  a.PostDirectional,
+		GridCoordinate:   a.GridCoordinate,
+		GridDirection:    a.GridDirection,
+		UnitDesignator:   a.UnitDesignator,
+		Unit:             a.Unit,
+		Units:            a.Units,
+		MilitaryUnitType: a.MilitaryUnitType,
+		MilitaryUnit:     a.MilitaryUnit,
+		Route:            a.Route,
+		Box:              a.Box,
+	}
+
+	return s, l.check(a, street)
+}
+
+// Extract attempts to extract an address from a string with surrounding words.
+func (p *Parser) Extract(in string) string {
+	addressMinCharacters := 2
+	s := strings.Replace(in, "  ", " ", -1)
+	x := strings.Split(s, " ")
+
+	for i := 0; i <= len(x); i++ {
+		str := func(words []string, offset int) string {
+
+			loops := len(words) - offset
+			for i := 0; i < loops; i++ {
+				// Enough words for an address
+				if len(words[i:loops]) > addressMinCharacters {
+					str := strings.Join(words[i:loops], " ")
+					addr, _ := p.Parse(str)
+					if addr.StreetType != "" && addr.HouseNumber != "" {
+						return str
+					}
+				}
+			}
+
+			return ""
+		}(x, i)
+
+		if str != "" {
+			return str
+		}
+	}
+
+	return ""
+}
+
+func (p *Parser) parse(address string) (*labeling, *Address, error) {
+	return p.parseWith(address, labelOptions{})
+}
+
+func (p *Parser) parseWith(address string, opts labelOptions) (*labeling, *Address, error) {
+	stripped := regexp.MustCompile(`/\s+/ /`).ReplaceAllString(address, "")
+	stripped = regexp.MustCompile(`\.`).ReplaceAllString(stripped, "")
+
+	upper := strings.ToUpper(stripped)
+	a := &Address{Original: upper}
+	if p.originalCase {
+		a.Original = stripped
+	}
+
+	tokens := tokenize(stripped)
+	if p.lenient {
+		a.Corrections = p.correctTokens(tokens)
+	}
+
+	l := p.label(tokens, false, opts)
+	l.assemble(a, p.originalCase)
+	if p.lenient {
+		p.correctCity(a, l.raw(labelCity))
+	}
+
+	if p.enrich || p.complete {
+		p.completeAddress(a)
+	}
+	if a.State == "" {
+		a.State = p.defaultState
+	}
+	if a.Country == "" {
+		a.Country = p.defaultCountry
+	}
+	a.Hash = HashAddress(a, p.hashAlgorithm)
+
+	err := l.check(a, address)
+	if err == nil && p.strict {
+		err = p.checkStrict(a, address)
+	}
+
+	return l, a, err
+}
+
+// checkStrict returns an error when a is missing a city, state or zip code.
+func (p *Parser) checkStrict(a *Address, input string) error {
+	if a.City == "" {
+		return newParseError(MissingCity, "", input)
+	} else if a.State == "" {
+		return newParseError(MissingState, "", input)
+	} else if a.PostalCode == "" {
+		return newParseError(MissingZip, "", input)
+	}
+
+	return nil
+}
+
+func (p *Parser) isStreetType(s string) bool {
+	_, ok := p.streetTypes[strings.ToUpper(s)]
+
+	return ok
+}
+
+// streetTypeStrength ranks how surely s is a street type, 0 if it isn't one.
+func (p *Parser) streetTypeStrength(s string) int {
+	s = strings.ToUpper(s)
+	if suffix, ok := p.streetTypes[s]; ok {
+		return suffix.strength(s)
+	}
+
+	return 0
+}
+
+// unitTerm returns the secondary unit designator s, nil if it isn't one.
+func (p *Parser) unitTerm(s string) *Term {
+	return p.unitDesignators[strings.ToUpper(strings.TrimSpace(s))]
+}
+
+// completeAddress enriches or completes an address, as configured,
+// keeping the casing of the parser.
+func (p *Parser) completeAddress(a *Address) {
+	city := a.City
+	if p.enrich {
+		Enrich(a)
+	}
+	if p.complete {
+		Complete(a)
+	}
+
+	if city == "" && p.originalCase {
+		a.City = strings.Title(strings.ToLower(a.City))
+	}
+}
@@ -0,0 +1,65 @@
+package godress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParserOptions(t *testing.T) {
+	p := NewParser(
+		WithStreetType("Crossover", "Xovr"),
+		WithUnitDesignator("Cbn", "Cabin"),
+		WithDefaultState("Utah"),
+		WithDefaultCountry("us"),
+		WithOriginalCase(),
+	)
+
+	a, err := p.Parse("123 Center Xovr Cabin 4 Lehi")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Address{
+		Kind:            KindStreet,
+		Original:        "123 Center Xovr Cabin 4 Lehi",
+		Hash:            a.Hash,
+		HouseNumber:     "123",
+		HouseNumberBase: "123",
+		StreetName:      "Center",
+		StreetType:      "Xovr",
+		UnitDesignator:  "Cabin",
+		Unit:            "4",
+		Units:           []SecondaryUnit{{Designator: "Cabin", Value: "4"}},
+		City:            "Lehi",
+		State:           "UT",
+		Country:         "US",
+	}
+	if !reflect.DeepEqual(a, expected) {
+		prettyPrint(t, expected, a)
+	}
+
+	// The default parser doesn't know the custom street type.
+	if a, _ := Parse("123 Center Xovr Lehi"); a.StreetType != "" {
+		t.Errorf("expected the default parser to be unchanged, got street type %q", a.StreetType)
+	}
+}
+
+func TestParserStrict(t *testing.T) {
+	p := NewParser(WithStrict())
+
+	tests := map[string]ParseErrorKind{
+		"123 Main St":                MissingCity,
+		"123 Main St Lehi":           MissingState,
+		"123 Main St Lehi, UT":       MissingZip,
+		"123 Main St Lehi, UT 84043": 0,
+	}
+
+	for s, kind := range tests {
+		_, err := p.Parse(s)
+		if kind == 0 && err != nil {
+			t.Errorf("%s: unexpected error %v", s, err)
+		} else if perr, ok := err.(*ParseError); kind != 0 && (!ok || perr.Kind != kind) {
+			t.Errorf("%s: expected %s, got %v", s, kind, err)
+		}
+	}
+}
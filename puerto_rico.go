@@ -0,0 +1,124 @@
+package godress
+
+import "strings"
+
+var (
+	// spanishPreTypes are the Spanish street types used in Puerto Rico,
+	// which come before the street name, i.e. CALLE A or CARR 2.
+	spanishPreTypes = []*streetPreType{
+		{"CALLE", "Calle", []string{"CLL"}, false},
+		{"AVENIDA", "Avenida", []string{"AVE", "AVDA", "AV"}, false},
+		{"CARRETERA", "Carretera", []string{"CARR", "CTRA"}, true},
+		{"CAMINO", "Camino", []string{"CAM"}, false},
+		{"PASEO", "Paseo", []string{"PSO"}, false},
+		{"BOULEVARD", "Boulevard", []string{"BLVD"}, false},
+	}
+
+	spanishPreTypesByAlias, _ = indexStreetPreTypes(spanishPreTypes)
+
+	// spanishDirections maps the Spanish directions to their abbreviations.
+	spanishDirections = map[string]string{
+		"NORTE":    "N",
+		"SUR":      "S",
+		"ESTE":     "E",
+		"OESTE":    "W",
+		"NORESTE":  "NE",
+		"NOROESTE": "NW",
+		"SURESTE":  "SE",
+		"SUROESTE": "SW",
+	}
+
+	// urbanizationPrefixes start the urbanization of a Puerto Rico address,
+	// the neighborhood that tells apart streets of the same name.
+	urbanizationPrefixes = map[string]bool{
+		"URB":          true,
+		"URBANIZACION": true,
+		"URBANIZACIÓN": true,
+	}
+
+	// puertoRicoZipPrefixes are the zip code prefixes of Puerto Rico.
+	puertoRicoZipPrefixes = []string{"006", "007", "009"}
+)
+
+// isPuertoRico reports whether the state or zip code is in Puerto Rico.
+func isPuertoRico(state, zip string) bool {
+	if strings.EqualFold(state, "PR") {
+		return true
+	}
+
+	for _, prefix := range puertoRicoZipPrefixes {
+		if strings.HasPrefix(zip, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// labelUrbanization labels the urbanization at the start of the tokens,
+// up to the house number, returning the index of the first token after it.
+func (l *labeling) labelUrbanization() int {
+	if len(l.tokens) < 2 || !urbanizationPrefixes[l.tokens[0].text] {
+		return 0
+	}
+
+	l.tokens[0].set(labelIgnored, ruleDictionary)
+
+	i := 1
+	for ; i < len(l.tokens); i++ {
+		t := l.tokens[i]
+		if i > 1 && houseNumberAt(l.tokens[i:]) > 0 {
+			break
+		}
+
+		t.set(labelUrbanization, ruleKeyword)
+		if t.comma {
+			i++
+			break
+		}
+	}
+
+	l.spanish = true
+
+	return i
+}
+
+// inPuertoRico reports whether the labeled state or zip code is in Puerto Rico.
+func (l *labeling) inPuertoRico() bool {
+	var state []string
+	var zip string
+	for _, t := range l.tokens {
+		switch t.label {
+		case labelState:
+			state = append(state, t.text)
+		case labelPostalCode:
+			zip = t.text
+		}
+	}
+
+	return isPuertoRico(StateAbbreviation(strings.Join(state, " ")), zip)
+}
+
+// isDirection reports whether s is a street direction, in Spanish too for
+// Puerto Rico addresses.
+func (l *labeling) isDirection(s string) bool {
+	if l.spanish && spanishDirections[s] != "" {
+		return true
+	}
+
+	return IsStreetDirection(s)
+}
+
+// preTypeAlias looks up a street pre type, preferring the Spanish ones for
+// Puerto Rico addresses.
+func (l *labeling) preTypeAlias(s string) (*streetPreType, bool) {
+	if l.spanish {
+		if preType, ok := spanishPreTypesByAlias[s]; ok {
+			return preType, true
+		}
+	}
+
+	preType, ok := streetPreTypesByAlias[s]
+
+	return preType, ok
+}
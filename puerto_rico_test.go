@@ -0,0 +1,40 @@
+package godress
+
+import "testing"
+
+func TestParsePuertoRico(t *testing.T) {
+	tests := map[string][5]string{
+		"URB Las Gladiolas 150 Calle A, San Juan, PR 00926": {"LAS GLADIOLAS", "CALLE", "A", "SAN JUAN", "URB LAS GLADIOLAS 150 CALLE A SAN JUAN, PR 00926"},
+		"URB LAS GLADIOLAS 150 CALLE A SAN JUAN PR 00926":   {"LAS GLADIOLAS", "CALLE", "A", "SAN JUAN", "URB LAS GLADIOLAS 150 CALLE A SAN JUAN, PR 00926"},
+		"1000 Avenida Ponce de Leon, San Juan, PR 00907":    {"", "AVENIDA", "PONCE DE LEON", "SAN JUAN", "1000 AVENIDA PONCE DE LEON SAN JUAN, PR 00907"},
+		"150 Avenida Ponce de Leon San Juan PR":             {"", "AVENIDA", "PONCE DE LEON", "SAN JUAN", "150 AVENIDA PONCE DE LEON SAN JUAN, PR"},
+		"1000 Ave Ponce de Leon, San Juan, 00907":           {"", "AVENIDA", "PONCE DE LEON", "SAN JUAN", "1000 AVENIDA PONCE DE LEON SAN JUAN 00907"},
+		"5 Carr 2, Bayamon, PR 00961":                       {"", "CARRETERA", "2", "BAYAMON", "5 CARRETERA 2 BAYAMON, PR 00961"},
+		"200 Paseo Las Palmas, Guaynabo, PR 00969":          {"", "PASEO", "LAS PALMAS", "GUAYNABO", "200 PASEO LAS PALMAS GUAYNABO, PR 00969"},
+		"12 Calle 5 Norte, Ponce, PR 00731":                 {"", "CALLE", "5", "PONCE", "12 CALLE 5 NORTE PONCE, PR 00731"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := [5]string{a.Urbanization, a.StreetPreType, a.StreetName, a.City, a.String()}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	// Spanish directions and street types are only looked for in Puerto Rico.
+	if a, _ := Parse("123 Este St, Lehi, UT"); a.StreetName != "ESTE" || a.StreetType != "ST" {
+		t.Errorf("expected ESTE St, got %q %q", a.StreetName, a.StreetType)
+	}
+
+	s, err := ParseStreet("URB Las Gladiolas 150 Calle A")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.Urbanization != "Las Gladiolas" || s.String() != "URB Las Gladiolas 150 Calle A" {
+		t.Errorf("expected URB Las Gladiolas 150 Calle A, got %q", s.String())
+	}
+}
@@ -0,0 +1,193 @@
+package godress
+
+// Field names a part of an address.
+type Field string
+
+// Address fields, named after their json keys.
+const (
+	FieldKind             Field = "kind"
+	FieldUrbanization     Field = "urbanization"
+	FieldHouseNumber      Field = "house_number"
+	FieldPreDirectional   Field = "pre_directional"
+	FieldStreetPreType    Field = "street_pre_type"
+	FieldStreetName       Field = "street_name"
+	FieldStreetType       Field = "street_type"
+	FieldPostDirectional  Field = "post_directional"
+	FieldUnitDesignator   Field = "unit_designator"
+	FieldUnit             Field = "unit"
+	FieldCity             Field = "city"
+	FieldCounty           Field = "county"
+	FieldState            Field = "state"
+	FieldPostalCode       Field = "postal_code"
+	FieldRoute            Field = "route"
+	FieldBox              Field = "box"
+	FieldMilitaryUnitType Field = "military_unit_type"
+	FieldMilitaryUnit     Field = "military_unit"
+	FieldCountry          Field = "country"
+)
+
+var (
+	labelFields = map[label]Field{
+		labelUrbanization:     FieldUrbanization,
+		labelHouseNumber:      FieldHouseNumber,
+		labelPreDirectional:   FieldPreDirectional,
+		labelStreetPreType:    FieldStreetPreType,
+		labelStreetName:       FieldStreetName,
+		labelStreetType:       FieldStreetType,
+		labelPostDirectional:  FieldPostDirectional,
+		labelUnitDesignator:   FieldUnitDesignator,
+		labelUnit:             FieldUnit,
+		labelCity:             FieldCity,
+		labelState:            FieldState,
+		labelPostalCode:       FieldPostalCode,
+		labelRoute:            FieldRoute,
+		labelBox:              FieldBox,
+		labelMilitaryUnitType: FieldMilitaryUnitType,
+		labelMilitaryUnit:     FieldMilitaryUnit,
+	}
+
+	ruleConfidence = map[rule]float64{
+		rulePosition:   0.6,
+		ruleBoundary:   0.75,
+		ruleKeyword:    0.85,
+		ruleDictionary: 0.9,
+		rulePattern:    0.95,
+	}
+)
+
+// Result is a parsed address along with how confident the parser is in
+// each of its fields, from 0 to 1.
+type Result struct {
+	Address    *Address          `json:"address"`
+	Confidence map[Field]float64 `json:"confidence"`
+	Score      float64           `json:"score"`
+}
+
+// ParseWithConfidence parses a string into an address, scoring each field
+// by the rule that found it, whether its words are also found in other
+// dictionaries and whether it agrees with the rest of the address.
+func ParseWithConfidence(address string) (*Result, error) {
+	return defaultParser.ParseWithConfidence(address)
+}
+
+// ParseWithConfidence parses a string into an address, scoring each field
+// like the package level ParseWithConfidence.
+func (p *Parser) ParseWithConfidence(address string) (*Result, error) {
+	l, a, err := p.parse(address)
+
+	return newResult(l, a, err), err
+}
+
+func newResult(l *labeling, a *Address, err error) *Result {
+	r := &Result{Address: a, Confidence: map[Field]float64{}}
+
+	// A field is as trustworthy as the weakest rule that labeled one of its
+	// tokens, less each token that could have belonged to another field.
+	penalty := map[Field]float64{}
+	words := map[Field]int{}
+	for i, t := range l.tokens {
+		field, ok := labelFields[t.label]
+		if !ok {
+			continue
+		}
+		words[field]++
+
+		if _, ok := penalty[field]; !ok {
+			penalty[field] = 1
+		}
+		penalty[field] *= tokenPenalty(l.p, t)
+
+		// A comma rarely falls in the middle of a field.
+		if t.comma && i+1 < len(l.tokens) && l.tokens[i+1].label == t.label {
+			penalty[field] *= 0.7
+		}
+
+		c := ruleConfidence[t.rule]
+		if prev, ok := r.Confidence[field]; !ok || c < prev {
+			r.Confidence[field] = c
+		}
+	}
+
+	for field, by := range penalty {
+		r.scale(field, by)
+	}
+
+	if l.kind == KindPoBox {
+		r.Confidence[FieldStreetName] = ruleConfidence[ruleDictionary]
+	}
+
+	// Fields that should come in pairs are less trustworthy alone.
+	if a.StreetType == "" && a.StreetPreType == "" && a.PreDirectional == "" && a.PostDirectional == "" && l.kind == KindStreet {
+		r.scale(FieldStreetName, 0.8)
+	}
+	// A corrected word is a guess at what was meant.
+	for _, c := range a.Corrections {
+		r.scale(c.Field, 0.8)
+	}
+
+	if a.State == "" {
+		r.scale(FieldCity, 0.8)
+		r.scale(FieldPostalCode, 0.9)
+	}
+	if a.PostalCode == "" {
+		r.scale(FieldState, 0.9)
+	}
+
+	// The overall score weighs each field by the words it covers, so every
+	// reading of the same input is scored over the same words.
+	total := 0
+	for field, c := range r.Confidence {
+		n := words[field]
+		if n == 0 {
+			n = 1
+		}
+		r.Score += c * float64(n)
+		total += n
+	}
+	if total > 0 {
+		r.Score /= float64(total)
+	}
+	if err != nil {
+		r.Score /= 2
+	}
+
+	return r
+}
+
+func (r *Result) scale(field Field, by float64) {
+	if c, ok := r.Confidence[field]; ok {
+		r.Confidence[field] = c * by
+	}
+}
+
+// tokenPenalty lowers the confidence of a token that could have belonged
+// to another field.
+func tokenPenalty(p *Parser, t *token) float64 {
+	penalty := 1.0
+
+	switch t.label {
+	case labelStreetName, labelCity:
+		switch p.streetTypeStrength(t.text) {
+		case 3:
+			penalty *= 0.5
+		case 2:
+			penalty *= 0.75
+		case 1:
+			penalty *= 0.9
+		}
+
+		if IsStreetDirection(t.text) || IsState(t.text) {
+			penalty *= 0.85
+		}
+	case labelStreetType:
+		if p.streetTypeStrength(t.text) == 1 {
+			penalty *= 0.9
+		}
+	case labelState:
+		if p.isStreetType(t.text) {
+			penalty *= 0.85
+		}
+	}
+
+	return penalty
+}
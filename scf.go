@@ -0,0 +1,110 @@
+package godress
+
+// scfRange is a range of 3 digit zip code prefixes, the sectional center
+// facilities, and the states they deliver to.
+type scfRange struct {
+	from, to int
+	states   []string
+}
+
+var (
+	scfRanges = []scfRange{
+		{5, 5, []string{"NY"}},
+		{6, 7, []string{"PR"}},
+		{8, 8, []string{"VI"}},
+		{9, 9, []string{"PR"}},
+		{10, 27, []string{"MA"}},
+		{28, 29, []string{"RI"}},
+		{30, 38, []string{"NH"}},
+		{39, 49, []string{"ME"}},
+		{50, 54, []string{"VT"}},
+		{55, 55, []string{"MA"}},
+		{56, 59, []string{"VT"}},
+		{60, 69, []string{"CT"}},
+		{70, 89, []string{"NJ"}},
+		{90, 98, []string{"AE"}},
+		{100, 149, []string{"NY"}},
+		{150, 196, []string{"PA"}},
+		{197, 199, []string{"DE"}},
+		{200, 200, []string{"DC"}},
+		{201, 201, []string{"VA"}},
+		{202, 205, []string{"DC"}},
+		{206, 219, []string{"MD"}},
+		{220, 246, []string{"VA"}},
+		{247, 268, []string{"WV"}},
+		{270, 289, []string{"NC"}},
+		{290, 299, []string{"SC"}},
+		{300, 319, []string{"GA"}},
+		{320, 339, []string{"FL"}},
+		{340, 340, []string{"AA"}},
+		{341, 349, []string{"FL"}},
+		{350, 369, []string{"AL"}},
+		{370, 385, []string{"TN"}},
+		{386, 397, []string{"MS"}},
+		{398, 399, []string{"GA"}},
+		{400, 427, []string{"KY"}},
+		{430, 459, []string{"OH"}},
+		{460, 479, []string{"IN"}},
+		{480, 499, []string{"MI"}},
+		{500, 528, []string{"IA"}},
+		{530, 549, []string{"WI"}},
+		{550, 567, []string{"MN"}},
+		{569, 569, []string{"DC"}},
+		{570, 577, []string{"SD"}},
+		{580, 588, []string{"ND"}},
+		{590, 599, []string{"MT"}},
+		{600, 629, []string{"IL"}},
+		{630, 658, []string{"MO"}},
+		{660, 679, []string{"KS"}},
+		{680, 693, []string{"NE"}},
+		{700, 714, []string{"LA"}},
+		{716, 729, []string{"AR"}},
+		{730, 732, []string{"OK"}},
+		{733, 733, []string{"TX"}},
+		{734, 749, []string{"OK"}},
+		{750, 799, []string{"TX"}},
+		{800, 816, []string{"CO"}},
+		{820, 831, []string{"WY"}},
+		{832, 838, []string{"ID"}},
+		{840, 847, []string{"UT"}},
+		{850, 865, []string{"AZ"}},
+		{870, 884, []string{"NM"}},
+		{885, 885, []string{"TX"}},
+		{889, 898, []string{"NV"}},
+		{900, 961, []string{"CA"}},
+		{962, 966, []string{"AP"}},
+		{967, 967, []string{"HI", "AS"}},
+		{968, 968, []string{"HI"}},
+		{969, 969, []string{"GU", "MP", "PW", "MH", "FM"}},
+		{970, 979, []string{"OR"}},
+		{980, 994, []string{"WA"}},
+		{995, 999, []string{"AK"}},
+	}
+
+	scfStates = indexSCFRanges(scfRanges)
+)
+
+// indexSCFRanges maps each 3 digit zip code prefix to its states.
+func indexSCFRanges(ranges []scfRange) map[int][]string {
+	index := map[int][]string{}
+	for _, r := range ranges {
+		for prefix := r.from; prefix <= r.to; prefix++ {
+			index[prefix] = r.states
+		}
+	}
+
+	return index
+}
+
+// ZipStates returns the states a zip code can be in, going by its first 3
+// digits, or nil when they aren't in use.
+func ZipStates(zip string) []string {
+	zip, _, _ = splitZipcode(zip)
+	if len(zip) < 3 || !isDigits(zip[:3]) {
+		return nil
+	}
+
+	prefix := int(zip[0]-'0')*100 + int(zip[1]-'0')*10 + int(zip[2]-'0')
+
+	return scfStates[prefix]
+}
@@ -0,0 +1,137 @@
+package godress
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Standardize returns a copy of the address in USPS Publication 28 form:
+// uppercase, without punctuation, and with its directions, English or
+// Spanish like NORTE, street type, unit designators and state abbreviated,
+// the way CanonicalKey and Match compare them. Street pre types keep their
+// spelled out standard form, like COUNTY ROAD, and a unit without a
+// designator gets #. The address itself is left as is.
+func (a *Address) Standardize() *Address {
+	s := *a
+
+	s.Urbanization = uspsText(a.Urbanization)
+	s.HouseNumber = uspsText(a.HouseNumber)
+	s.HouseNumberBase = uspsText(a.HouseNumberBase)
+	s.HouseNumberSuffix = uspsText(a.HouseNumberSuffix)
+	s.HouseNumberFraction = uspsText(a.HouseNumberFraction)
+	s.HouseNumberRangeEnd = uspsText(a.HouseNumberRangeEnd)
+	s.PreDirectional = uspsText(directionAbbr(a.PreDirectional))
+	s.StreetPreType = uspsText(StreetPreTypeName(a.StreetPreType))
+	s.StreetName = uspsText(a.StreetName)
+	s.StreetType = uspsText(StreetTypeAbbr(a.StreetType))
+	s.PostDirectional = uspsText(directionAbbr(a.PostDirectional))
+	s.GridCoordinate = uspsText(a.GridCoordinate)
+	s.GridDirection = uspsText(directionAbbr(a.GridDirection))
+	s.StreetDirection = s.PreDirectional
+	if s.StreetDirection == "" {
+		s.StreetDirection = s.PostDirectional
+	}
+
+	units := a.Units
+	if len(units) == 0 && a.Unit != "" {
+		units = []SecondaryUnit{{Designator: a.UnitDesignator, Value: a.Unit}}
+	}
+	s.Units, s.UnitDesignator, s.Unit = nil, "", ""
+	for _, u := range units {
+		designator := uspsText(unitAbbr(u.Designator))
+		if designator == "" {
+			designator = "#"
+		}
+		s.Units = append(s.Units, SecondaryUnit{Designator: designator, Value: uspsText(strings.TrimPrefix(u.Value, "#"))})
+	}
+	if len(s.Units) > 0 {
+		s.UnitDesignator, s.Unit = s.Units[len(s.Units)-1].Designator, s.Units[len(s.Units)-1].Value
+	}
+
+	s.City = uspsText(a.City)
+	s.County = uspsText(a.County)
+	s.State = StateAbbreviation(a.State)
+	if s.State == "" {
+		s.State = uspsText(a.State)
+	}
+
+	zip, ext, dp := splitZipcode(a.PostalCode)
+	s.PostalCode = zip
+	if s.PostalCodeExt == "" {
+		s.PostalCodeExt, s.DeliveryPoint = ext, dp
+	}
+
+	s.Route = uspsText(a.Route)
+	s.Box = uspsText(a.Box)
+	s.MilitaryUnitType = uspsText(a.MilitaryUnitType)
+	s.MilitaryUnit = uspsText(a.MilitaryUnit)
+	s.Country = uspsText(a.Country)
+
+	s.Inferred = append([]Field(nil), a.Inferred...)
+	s.ZipSuggestions = append([]string(nil), a.ZipSuggestions...)
+	s.Corrections = append([]Correction(nil), a.Corrections...)
+
+	return &s
+}
+
+// DeliveryLine returns the first line of the address in Publication 28
+// form, i.e. 123 N MAIN ST APT 4, PO BOX 12 or PSC 1234 BOX 5678. The
+// urbanization of a Puerto Rico address goes on a line of its own, above
+// it.
+func (a *Address) DeliveryLine() string {
+	s := a.Standardize()
+
+	var parts []string
+	switch {
+	case s.Kind == KindMilitary:
+		parts = []string{formatMilitary(s.MilitaryUnitType, s.MilitaryUnit, s.Box)}
+	case s.Kind.isRoute():
+		parts = []string{formatRoute(s.Kind, s.Route, s.Box)}
+	case s.Kind == KindPoBox || s.StreetName == "PO BOX":
+		box := s.Box
+		if box == "" {
+			box = s.HouseNumber
+		}
+		parts = []string{"PO BOX", box}
+	case s.GridCoordinate != "":
+		parts = []string{s.HouseNumber, s.PreDirectional, s.GridCoordinate, s.GridDirection}
+	default:
+		parts = []string{s.HouseNumber, s.PreDirectional, s.StreetPreType, s.StreetName, s.StreetType, s.PostDirectional}
+	}
+
+	for _, u := range s.Units {
+		parts = append(parts, u.Designator, u.Value)
+	}
+
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
+
+// LastLine returns the last line of the address in Publication 28 form,
+// the city, state and ZIP+4 without a comma, i.e. LEHI UT 84043-1234.
+func (a *Address) LastLine() string {
+	s := a.Standardize()
+
+	zip := s.PostalCode
+	if zip != "" && s.PostalCodeExt != "" {
+		zip += "-" + s.PostalCodeExt
+	}
+
+	return strings.Join(strings.Fields(s.City+" "+s.State+" "+zip), " ")
+}
+
+// uspsText uppercases s and drops its punctuation, keeping the hyphens of
+// house number ranges and the slashes of fractions.
+func uspsText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '/' || r == '#':
+			return unicode.ToUpper(r)
+		case unicode.IsSpace(r):
+			return ' '
+		}
+
+		return -1
+	}, s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
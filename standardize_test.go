@@ -0,0 +1,57 @@
+package godress
+
+import "testing"
+
+func TestStandardize(t *testing.T) {
+	tests := map[string][2]string{
+		"123 North Main Street Apartment 4, Lehi, Utah 84043-1234": {"123 N MAIN ST APT 4", "LEHI UT 84043-1234"},
+		"123 Main St #4B Lehi, UT 84043":                           {"123 MAIN ST # 4B", "LEHI UT 84043"},
+		"123 Main St. Suite 200, Lehi, UT 840431234":               {"123 MAIN ST STE 200", "LEHI UT 84043-1234"},
+		"4400 Co Rd 12, Lehi, UT 84043":                            {"4400 COUNTY ROAD 12", "LEHI UT 84043"},
+		"137 North 800 East Street, Spanish Fork, UT 84660":        {"137 N 800 E", "SPANISH FORK UT 84660"},
+		"P.O. Box 12, Lehi, UT 84043":                              {"PO BOX 12", "LEHI UT 84043"},
+		"Rural Route 2 Box 15, Lehi, UT 84043":                     {"RR 2 BOX 15", "LEHI UT 84043"},
+		"PSC 1234, Box 5678, APO, AE 09204":                        {"PSC 1234 BOX 5678", "APO AE 09204"},
+		"123 Main St Bldg 3 Apt 12 Lehi UT":                        {"123 MAIN ST BLDG 3 APT 12", "LEHI UT"},
+		"150 Calle Sol Norte, San Juan PR 00901":                   {"150 CALLE SOL N", "SAN JUAN PR 00901"},
+	}
+
+	p := NewParser(WithOriginalCase())
+	for s, expected := range tests {
+		a, err := p.Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if got := [2]string{a.DeliveryLine(), a.LastLine()}; got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	a, _ := p.Parse("URB Las Gladiolas 150 Calle A, San Juan, PR 00926")
+	if got, expected := a.Format(FormatUSPS), "URB LAS GLADIOLAS\n150 CALLE A\nSAN JUAN PR 00926"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	a, _ = p.Parse("123 North Main Street Apartment 4, Lehi, Utah")
+	s := a.Standardize()
+	if s.PreDirectional != "N" || s.StreetType != "ST" || s.UnitDesignator != "APT" || s.City != "LEHI" || s.State != "UT" {
+		t.Errorf("expected standardized fields, got %+v", s)
+	}
+	if a.StreetType != "Street" || a.Units[0].Designator != "Apartment" {
+		t.Errorf("expected Standardize to leave the address as is, got %+v", a)
+	}
+}
+
+func TestStreetStringUnit(t *testing.T) {
+	s, err := ParseStreet("123 Main St Apt 4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := s.String(); got != "123 Main St Apt 4" {
+			t.Errorf("expected 123 Main St Apt 4, got %q", got)
+		}
+	}
+}
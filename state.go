@@ -1,74 +1,152 @@
 package godress
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
+
+// StateKind classifies the places that have a state abbreviation.
+type StateKind int
+
+const (
+	// StateKindState is one of the 50 states.
+	StateKindState StateKind = iota + 1
+	// StateKindDistrict is the District of Columbia.
+	StateKindDistrict
+	// StateKindTerritory is a US territory, like Puerto Rico or Guam.
+	StateKindTerritory
+	// StateKindFreelyAssociated is a freely associated state, like Palau,
+	// which is served by the US Postal Service.
+	StateKindFreelyAssociated
+	// StateKindMilitary is an armed forces state of APO, FPO and DPO addresses.
+	StateKindMilitary
+)
+
+var stateKindNames = map[StateKind]string{
+	StateKindState:            "state",
+	StateKindDistrict:         "district",
+	StateKindTerritory:        "territory",
+	StateKindFreelyAssociated: "freely associated state",
+	StateKindMilitary:         "military",
+}
+
+// String returns a human readable description of the kind.
+func (k StateKind) String() string {
+	if name, ok := stateKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("StateKind(%d)", int(k))
+}
+
+// State is a place with a state abbreviation, i.e. Utah or Puerto Rico.
+type State struct {
+	Name         string
+	Abbreviation string
+	Kind         StateKind
+}
 
 var (
-	States = map[string]string{
-		"alabama":        "al",
-		"alaska":         "ak",
-		"arizona":        "az",
-		"arkansas":       "ar",
-		"california":     "ca",
-		"colorado":       "co",
-		"connecticut":    "ct",
-		"delaware":       "de",
-		"florida":        "fl",
-		"georgia":        "ga",
-		"hawaii":         "hi",
-		"idaho":          "id",
-		"illinois":       "il",
-		"indiana":        "in",
-		"iowa":           "ia",
-		"kansas":         "ks",
-		"kentucky":       "ky",
-		"louisiana":      "la",
-		"maine":          "me",
-		"maryland":       "md",
-		"massachusetts":  "ma",
-		"michigan":       "mi",
-		"minnesota":      "mn",
-		"mississippi":    "ms",
-		"missouri":       "mo",
-		"montana":        "mt",
-		"nebraska":       "ne",
-		"nevada":         "nv",
-		"new hampshire":  "nh",
-		"new jersey":     "nj",
-		"new mexico":     "nm",
-		"new york":       "ny",
-		"north carolina": "nc",
-		"north dakota":   "nd",
-		"ohio":           "oh",
-		"oklahoma":       "ok",
-		"oregon":         "or",
-		"pennsylvania":   "pa",
-		"rhode island":   "ri",
-		"south carolina": "sc",
-		"south dakota":   "sd",
-		"tennessee":      "tn",
-		"texas":          "tx",
-		"utah":           "ut",
-		"vermont":        "vt",
-		"virginia":       "va",
-		"washington":     "wa",
-		"west virginia":  "wv",
-		"wisconsin":      "wi",
-		"wyoming":        "wy",
+	stateList = []State{
+		{"Alabama", "AL", StateKindState},
+		{"Alaska", "AK", StateKindState},
+		{"Arizona", "AZ", StateKindState},
+		{"Arkansas", "AR", StateKindState},
+		{"California", "CA", StateKindState},
+		{"Colorado", "CO", StateKindState},
+		{"Connecticut", "CT", StateKindState},
+		{"Delaware", "DE", StateKindState},
+		{"Florida", "FL", StateKindState},
+		{"Georgia", "GA", StateKindState},
+		{"Hawaii", "HI", StateKindState},
+		{"Idaho", "ID", StateKindState},
+		{"Illinois", "IL", StateKindState},
+		{"Indiana", "IN", StateKindState},
+		{"Iowa", "IA", StateKindState},
+		{"Kansas", "KS", StateKindState},
+		{"Kentucky", "KY", StateKindState},
+		{"Louisiana", "LA", StateKindState},
+		{"Maine", "ME", StateKindState},
+		{"Maryland", "MD", StateKindState},
+		{"Massachusetts", "MA", StateKindState},
+		{"Michigan", "MI", StateKindState},
+		{"Minnesota", "MN", StateKindState},
+		{"Mississippi", "MS", StateKindState},
+		{"Missouri", "MO", StateKindState},
+		{"Montana", "MT", StateKindState},
+		{"Nebraska", "NE", StateKindState},
+		{"Nevada", "NV", StateKindState},
+		{"New Hampshire", "NH", StateKindState},
+		{"New Jersey", "NJ", StateKindState},
+		{"New Mexico", "NM", StateKindState},
+		{"New York", "NY", StateKindState},
+		{"North Carolina", "NC", StateKindState},
+		{"North Dakota", "ND", StateKindState},
+		{"Ohio", "OH", StateKindState},
+		{"Oklahoma", "OK", StateKindState},
+		{"Oregon", "OR", StateKindState},
+		{"Pennsylvania", "PA", StateKindState},
+		{"Rhode Island", "RI", StateKindState},
+		{"South Carolina", "SC", StateKindState},
+		{"South Dakota", "SD", StateKindState},
+		{"Tennessee", "TN", StateKindState},
+		{"Texas", "TX", StateKindState},
+		{"Utah", "UT", StateKindState},
+		{"Vermont", "VT", StateKindState},
+		{"Virginia", "VA", StateKindState},
+		{"Washington", "WA", StateKindState},
+		{"West Virginia", "WV", StateKindState},
+		{"Wisconsin", "WI", StateKindState},
+		{"Wyoming", "WY", StateKindState},
+		{"District of Columbia", "DC", StateKindDistrict},
+		{"American Samoa", "AS", StateKindTerritory},
+		{"Guam", "GU", StateKindTerritory},
+		{"Northern Mariana Islands", "MP", StateKindTerritory},
+		{"Puerto Rico", "PR", StateKindTerritory},
+		{"Virgin Islands", "VI", StateKindTerritory},
+		{"Federated States of Micronesia", "FM", StateKindFreelyAssociated},
+		{"Marshall Islands", "MH", StateKindFreelyAssociated},
+		{"Palau", "PW", StateKindFreelyAssociated},
+		{"Armed Forces Americas", "AA", StateKindMilitary},
+		{"Armed Forces Europe", "AE", StateKindMilitary},
+		{"Armed Forces Pacific", "AP", StateKindMilitary},
 	}
+
+	// stateAliases are other names the places in stateList go by.
+	stateAliases = map[string]string{
+		"micronesia":                   "fm",
+		"us virgin islands":            "vi",
+		"united states virgin islands": "vi",
+		"northern marianas":            "mp",
+	}
+
+	// States maps the lowercase names of states, DC, territories and
+	// armed forces states to their lowercase abbreviations.
+	States = indexStates(stateList, stateAliases)
+
+	statesByAbbreviation = indexStateAbbreviations(stateList)
 )
 
-// IsState determines if the provided string is a valid state.
-func IsState(s string) bool {
-	if States[s] == "" {
-		for _, v := range States {
-			if strings.ToLower(s) == v {
-				return true
-			}
-		}
-		return false
+// indexStates maps the lowercase name of each state, and each alias, to
+// its lowercase abbreviation.
+func indexStates(states []State, aliases map[string]string) map[string]string {
+	index := map[string]string{}
+	for _, state := range states {
+		index[strings.ToLower(state.Name)] = strings.ToLower(state.Abbreviation)
 	}
+	for alias, abbr := range aliases {
+		index[alias] = abbr
+	}
+
+	return index
+}
+
+// IsState determines if the provided string is the name or abbreviation
+// of a state, DC, territory or armed forces state, in any case.
+func IsState(s string) bool {
+	_, ok := LookupState(s)
 
-	return true
+	return ok
 }
 
 // StateAbbreviation gets the 2 letter abbreviation for a state.
@@ -79,3 +157,45 @@ func StateAbbreviation(s string) string {
 
 	return strings.ToUpper(s)
 }
+
+// indexStateAbbreviations maps the abbreviation of each state to the state.
+func indexStateAbbreviations(states []State) map[string]State {
+	index := map[string]State{}
+	for _, state := range states {
+		index[state.Abbreviation] = state
+	}
+
+	return index
+}
+
+// LookupState finds a state, DC, territory or armed forces state by its
+// name or abbreviation, in any case.
+func LookupState(s string) (State, bool) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if abbr, ok := States[s]; ok {
+		s = abbr
+	}
+
+	state, ok := statesByAbbreviation[strings.ToUpper(s)]
+
+	return state, ok
+}
+
+// StateKindOf returns the kind of the state named or abbreviated s, or 0
+// when it isn't one.
+func StateKindOf(s string) StateKind {
+	state, _ := LookupState(s)
+
+	return state.Kind
+}
+
+// StatesOfKind returns every state of the given kind.
+func StatesOfKind(kind StateKind) (states []State) {
+	for _, state := range stateList {
+		if state.Kind == kind {
+			states = append(states, state)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,92 @@
+package godress
+
+import "testing"
+
+func TestStateKinds(t *testing.T) {
+	tests := map[string]StateKind{
+		"UT":                             StateKindState,
+		"west virginia":                  StateKindState,
+		"DC":                             StateKindDistrict,
+		"District of Columbia":           StateKindDistrict,
+		"PR":                             StateKindTerritory,
+		"guam":                           StateKindTerritory,
+		"US Virgin Islands":              StateKindTerritory,
+		"as":                             StateKindTerritory,
+		"Northern Mariana Islands":       StateKindTerritory,
+		"Federated States of Micronesia": StateKindFreelyAssociated,
+		"MH":                             StateKindFreelyAssociated,
+		"palau":                          StateKindFreelyAssociated,
+		"AE":                             StateKindMilitary,
+		"XX":                             0,
+	}
+
+	for s, kind := range tests {
+		if got := StateKindOf(s); got != kind {
+			t.Errorf("%s: expected %s, got %s", s, kind, got)
+		}
+	}
+
+	if state, ok := LookupState("puerto rico"); !ok || state.Abbreviation != "PR" || state.Name != "Puerto Rico" {
+		t.Errorf("expected Puerto Rico, got %+v", state)
+	}
+	if !IsState("dc") || StateAbbreviation("guam") != "GU" {
+		t.Errorf("expected DC and Guam to be states")
+	}
+	if n := len(StatesOfKind(StateKindState)); n != 50 {
+		t.Errorf("expected 50 states, got %d", n)
+	}
+}
+
+func TestIsState(t *testing.T) {
+	tests := map[string]bool{
+		"Utah":                           true,
+		"utah":                           true,
+		"UTAH":                           true,
+		"ut":                             true,
+		" New  York ":                    true,
+		"Guam":                           true,
+		"GUAM":                           true,
+		"Puerto Rico":                    true,
+		"puerto RICO":                    true,
+		"Us Virgin Islands":              true,
+		"Northern Mariana Islands":       true,
+		"Palau":                          true,
+		"Marshall Islands":               true,
+		"federated states of micronesia": true,
+		"Micronesia":                     true,
+		"Armed Forces Europe":            true,
+		"ae":                             true,
+		"":                               false,
+		"XX":                             false,
+		"Utahh":                          false,
+		"Main":                           false,
+	}
+
+	for s, expected := range tests {
+		if got := IsState(s); got != expected {
+			t.Errorf("%q: expected %t, got %t", s, expected, got)
+		}
+	}
+}
+
+func TestParseTerritories(t *testing.T) {
+	tests := map[string][3]string{
+		"1600 Pennsylvania Ave NW, Washington, DC 20500": {"WASHINGTON", "DC", "20500"},
+		"1 Calle Sol, San Juan, PR 00901":                {"SAN JUAN", "PR", "00901"},
+		"100 Marine Dr, Hagatna, GU 96910":               {"HAGATNA", "GU", "96910"},
+		"5 Main St, Charlotte Amalie, VI 00802":          {"CHARLOTTE AMALIE", "VI", "00802"},
+		"1 Main St, Majuro, Marshall Islands 96960":      {"MAJURO", "MH", "96960"},
+		"1 Main St, Holtsville, NY 00501":                {"HOLTSVILLE", "NY", "00501"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		if got := [3]string{a.City, a.State, a.PostalCode}; got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package godress
+
+import "strings"
+
+// streetPreType is a street type written before the street name, like
+// HIGHWAY in HIGHWAY 101 or CALLE in CALLE SOL, with its USPS standard form
+// and the spellings that map to it. A numbered pre type, like most highway
+// and route designators, is only a pre type when a route number follows it.
+type streetPreType struct {
+	standard string
+	title    string
+	aliases  []string
+	numbered bool
+}
+
+var (
+	streetPreTypes = []*streetPreType{
+		{"US HIGHWAY", "US Highway", []string{"US HWY", "US", "U S HIGHWAY", "U S HWY", "US ROUTE", "US RTE"}, true},
+		{"STATE ROUTE", "State Route", []string{"STATE RTE", "ST ROUTE", "ST RTE", "SR"}, true},
+		{"STATE HIGHWAY", "State Highway", []string{"STATE HWY", "ST HWY", "SH"}, true},
+		{"STATE ROAD", "State Road", []string{"STATE RD", "ST RD"}, true},
+		{"COUNTY ROAD", "County Road", []string{"COUNTY RD", "CO RD", "CNTY RD", "CR"}, true},
+		{"COUNTY HIGHWAY", "County Highway", []string{"COUNTY HWY", "CO HWY", "CNTY HWY"}, true},
+		{"FARM TO MARKET ROAD", "Farm To Market Road", []string{"FARM TO MARKET RD", "FARM TO MARKET", "FM"}, true},
+		{"RANCH ROAD", "Ranch Road", []string{"RANCH RD", "RR"}, true},
+		{"INTERSTATE", "Interstate", []string{"INTERSTATE HIGHWAY", "INTERSTATE HWY", "IH", "I"}, true},
+		{"HIGHWAY", "Highway", []string{"HWY"}, true},
+		{"ROUTE", "Route", []string{"RTE"}, true},
+		{"AVENUE", "Avenue", []string{"AVE"}, false},
+		{"CALLE", "Calle", nil, false},
+	}
+
+	streetPreTypesByAlias, streetPreTypeWords = indexStreetPreTypes(streetPreTypes)
+)
+
+// indexStreetPreTypes maps each pre type's standard form and aliases to the
+// pre type, also returning the most words any of them is written in.
+func indexStreetPreTypes(preTypes []*streetPreType) (map[string]*streetPreType, int) {
+	index := map[string]*streetPreType{}
+	words := 0
+	for _, preType := range preTypes {
+		for _, alias := range append([]string{preType.standard}, preType.aliases...) {
+			index[alias] = preType
+			if n := len(strings.Fields(alias)); n > words {
+				words = n
+			}
+		}
+	}
+
+	return index, words
+}
+
+// IsStreetPreType attempts to match string with the street types and
+// highway designators that are written before a street name, i.e.
+// Calle, Hwy or CR
+func IsStreetPreType(s string) bool {
+	_, ok := streetPreTypesByAlias[strings.ToUpper(strings.Join(strings.Fields(s), " "))]
+
+	return ok
+}
+
+// StreetPreTypeName takes any spelling of a street pre type i.e. CR or
+// Co Rd and returns its USPS standard form i.e. COUNTY ROAD
+// If no match is found, the supplied string is returned.
+func StreetPreTypeName(s string) string {
+	preType, ok := streetPreTypesByAlias[strings.ToUpper(strings.Join(strings.Fields(s), " "))]
+	if !ok {
+		return s
+	}
+
+	return preType.standard
+}
+
+// splitPreType splits a hyphenated route like I-35 or SR-9 into its
+// designator and number, returning nil when s isn't one.
+func splitPreType(s string) []string {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 || parts[1] == "" || !strings.ContainsAny(parts[1], "0123456789") {
+		return nil
+	}
+
+	if preType, ok := streetPreTypesByAlias[strings.ToUpper(parts[0])]; !ok || !preType.numbered {
+		return nil
+	}
+
+	return parts
+}
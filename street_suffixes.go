@@ -0,0 +1,247 @@
+package godress
+
+// streetSuffix is a street type from USPS Publication 28 Appendix C1, with
+// its primary name, the Postal Service standard abbreviation and the
+// commonly used spellings that map to it.
+type streetSuffix struct {
+	primary string
+	abbr    string
+	aliases []string
+}
+
+var (
+	streetSuffixes = []*streetSuffix{
+		{"ALLEY", "ALY", []string{"ALLEE", "ALLY"}},
+		{"ANEX", "ANX", []string{"ANNEX", "ANNX"}},
+		{"ARCADE", "ARC", nil},
+		{"AVENUE", "AVE", []string{"AV", "AVEN", "AVENU", "AVN", "AVNUE"}},
+		{"BAYOU", "BYU", []string{"BAYOO"}},
+		{"BEACH", "BCH", nil},
+		{"BEND", "BND", nil},
+		{"BLUFF", "BLF", []string{"BLUF"}},
+		{"BLUFFS", "BLFS", nil},
+		{"BOTTOM", "BTM", []string{"BOT", "BOTTM"}},
+		{"BOULEVARD", "BLVD", []string{"BOUL", "BOULV"}},
+		{"BRANCH", "BR", []string{"BRNCH"}},
+		{"BRIDGE", "BRG", []string{"BRDGE"}},
+		{"BROOK", "BRK", nil},
+		{"BROOKS", "BRKS", nil},
+		{"BURG", "BG", nil},
+		{"BURGS", "BGS", nil},
+		{"BYPASS", "BYP", []string{"BYPA", "BYPAS", "BYPS"}},
+		{"CAMP", "CP", []string{"CMP"}},
+		{"CANYON", "CYN", []string{"CANYN", "CNYN"}},
+		{"CAPE", "CPE", nil},
+		{"CAUSEWAY", "CSWY", []string{"CAUSWA"}},
+		{"CENTER", "CTR", []string{"CEN", "CENT", "CENTR", "CENTRE", "CNTER", "CNTR"}},
+		{"CENTERS", "CTRS", nil},
+		{"CIRCLE", "CIR", []string{"CIRC", "CIRCL", "CRCL", "CRCLE"}},
+		{"CIRCLES", "CIRS", nil},
+		{"CLIFF", "CLF", nil},
+		{"CLIFFS", "CLFS", nil},
+		{"CLUB", "CLB", nil},
+		{"COMMON", "CMN", nil},
+		{"COMMONS", "CMNS", nil},
+		{"CORNER", "COR", nil},
+		{"CORNERS", "CORS", nil},
+		{"COURSE", "CRSE", nil},
+		{"COURT", "CT", []string{"CRT"}},
+		{"COURTS", "CTS", nil},
+		{"COVE", "CV", nil},
+		{"COVES", "CVS", nil},
+		{"CREEK", "CRK", nil},
+		{"CRESCENT", "CRES", []string{"CRSENT", "CRSNT"}},
+		{"CREST", "CRST", nil},
+		{"CROSSING", "XING", []string{"CRSSNG"}},
+		{"CROSSROAD", "XRD", nil},
+		{"CROSSROADS", "XRDS", nil},
+		{"CURVE", "CURV", nil},
+		{"DALE", "DL", nil},
+		{"DAM", "DM", nil},
+		{"DIVIDE", "DV", []string{"DIV", "DVD"}},
+		{"DRIVE", "DR", []string{"DRIV", "DRV"}},
+		{"DRIVES", "DRS", nil},
+		{"ESTATE", "EST", nil},
+		{"ESTATES", "ESTS", nil},
+		{"EXPRESSWAY", "EXPY", []string{"EXP", "EXPR", "EXPRESS", "EXPW"}},
+		{"EXTENSION", "EXT", []string{"EXTN", "EXTNSN"}},
+		{"EXTENSIONS", "EXTS", nil},
+		{"FALL", "FALL", nil},
+		{"FALLS", "FLS", nil},
+		{"FERRY", "FRY", []string{"FRRY"}},
+		{"FIELD", "FLD", nil},
+		{"FIELDS", "FLDS", nil},
+		{"FLAT", "FLT", nil},
+		{"FLATS", "FLTS", nil},
+		{"FORD", "FRD", nil},
+		{"FORDS", "FRDS", nil},
+		{"FOREST", "FRST", []string{"FORESTS"}},
+		{"FORGE", "FRG", []string{"FORG"}},
+		{"FORGES", "FRGS", nil},
+		{"FORK", "FRK", nil},
+		{"FORKS", "FRKS", nil},
+		{"FORT", "FT", []string{"FRT"}},
+		{"FREEWAY", "FWY", []string{"FREEWY", "FRWAY", "FRWY"}},
+		{"GARDEN", "GDN", []string{"GARDN", "GRDEN", "GRDN"}},
+		{"GARDENS", "GDNS", []string{"GRDNS"}},
+		{"GATEWAY", "GTWY", []string{"GATEWY", "GATWAY", "GTWAY"}},
+		{"GLEN", "GLN", nil},
+		{"GLENS", "GLNS", nil},
+		{"GREEN", "GRN", nil},
+		{"GREENS", "GRNS", nil},
+		{"GROVE", "GRV", []string{"GROV"}},
+		{"GROVES", "GRVS", nil},
+		{"HARBOR", "HBR", []string{"HARB", "HARBR", "HRBOR"}},
+		{"HARBORS", "HBRS", nil},
+		{"HAVEN", "HVN", nil},
+		{"HEIGHTS", "HTS", []string{"HT"}},
+		{"HIGHWAY", "HWY", []string{"HIGHWY", "HIWAY", "HIWY", "HWAY"}},
+		{"HILL", "HL", nil},
+		{"HILLS", "HLS", nil},
+		{"HOLLOW", "HOLW", []string{"HLLW", "HOLLOWS", "HOLWS"}},
+		{"INLET", "INLT", nil},
+		{"ISLAND", "IS", []string{"ISLND"}},
+		{"ISLANDS", "ISS", []string{"ISLNDS"}},
+		{"ISLE", "ISLE", []string{"ISLES"}},
+		{"JUNCTION", "JCT", []string{"JCTION", "JCTN", "JUNCTN", "JUNCTON"}},
+		{"JUNCTIONS", "JCTS", []string{"JCTNS"}},
+		{"KEY", "KY", nil},
+		{"KEYS", "KYS", nil},
+		{"KNOLL", "KNL", []string{"KNOL"}},
+		{"KNOLLS", "KNLS", nil},
+		{"LAKE", "LK", nil},
+		{"LAKES", "LKS", nil},
+		{"LAND", "LAND", nil},
+		{"LANDING", "LNDG", []string{"LNDNG"}},
+		{"LANE", "LN", nil},
+		{"LIGHT", "LGT", nil},
+		{"LIGHTS", "LGTS", nil},
+		{"LOAF", "LF", nil},
+		{"LOCK", "LCK", nil},
+		{"LOCKS", "LCKS", nil},
+		{"LODGE", "LDG", []string{"LDGE", "LODG"}},
+		{"LOOP", "LOOP", []string{"LOOPS"}},
+		{"MALL", "MALL", nil},
+		{"MANOR", "MNR", nil},
+		{"MANORS", "MNRS", nil},
+		{"MEADOW", "MDW", nil},
+		{"MEADOWS", "MDWS", []string{"MEDOWS"}},
+		{"MEWS", "MEWS", nil},
+		{"MILL", "ML", nil},
+		{"MILLS", "MLS", nil},
+		{"MISSION", "MSN", []string{"MISSN", "MSSN"}},
+		{"MOTORWAY", "MTWY", nil},
+		{"MOUNT", "MT", []string{"MNT"}},
+		{"MOUNTAIN", "MTN", []string{"MNTAIN", "MNTN", "MOUNTIN", "MTIN"}},
+		{"MOUNTAINS", "MTNS", []string{"MNTNS"}},
+		{"NECK", "NCK", nil},
+		{"ORCHARD", "ORCH", []string{"ORCHRD"}},
+		{"OVAL", "OVAL", []string{"OVL"}},
+		{"OVERPASS", "OPAS", nil},
+		{"PARK", "PARK", []string{"PRK", "PARKS"}},
+		{"PARKWAY", "PKWY", []string{"PARKWY", "PKWAY", "PKY", "PARKWAYS", "PKWYS"}},
+		{"PASS", "PASS", nil},
+		{"PASSAGE", "PSGE", nil},
+		{"PATH", "PATH", []string{"PATHS"}},
+		{"PIKE", "PIKE", []string{"PIKES"}},
+		{"PINE", "PNE", nil},
+		{"PINES", "PNES", nil},
+		{"PLACE", "PL", nil},
+		{"PLAIN", "PLN", nil},
+		{"PLAINS", "PLNS", nil},
+		{"PLAZA", "PLZ", []string{"PLZA"}},
+		{"POINT", "PT", nil},
+		{"POINTS", "PTS", nil},
+		{"PORT", "PRT", nil},
+		{"PORTS", "PRTS", nil},
+		{"PRAIRIE", "PR", []string{"PRR"}},
+		{"RADIAL", "RADL", []string{"RAD", "RADIEL"}},
+		{"RAMP", "RAMP", nil},
+		{"RANCH", "RNCH", []string{"RANCHES", "RNCHS"}},
+		{"RAPID", "RPD", nil},
+		{"RAPIDS", "RPDS", nil},
+		{"REST", "RST", nil},
+		{"RIDGE", "RDG", []string{"RDGE"}},
+		{"RIDGES", "RDGS", nil},
+		{"RIVER", "RIV", []string{"RVR", "RIVR"}},
+		{"ROAD", "RD", nil},
+		{"ROADS", "RDS", nil},
+		{"ROUTE", "RTE", nil},
+		{"ROW", "ROW", nil},
+		{"RUE", "RUE", nil},
+		{"RUN", "RUN", nil},
+		{"SHOAL", "SHL", nil},
+		{"SHOALS", "SHLS", nil},
+		{"SHORE", "SHR", []string{"SHOAR"}},
+		{"SHORES", "SHRS", []string{"SHOARS"}},
+		{"SKYWAY", "SKWY", nil},
+		{"SPRING", "SPG", []string{"SPNG", "SPRNG"}},
+		{"SPRINGS", "SPGS", []string{"SPNGS", "SPRNGS"}},
+		{"SPUR", "SPUR", []string{"SPURS"}},
+		{"SQUARE", "SQ", []string{"SQR", "SQRE", "SQU"}},
+		{"SQUARES", "SQS", []string{"SQRS"}},
+		{"STATION", "STA", []string{"STATN", "STN"}},
+		{"STRAVENUE", "STRA", []string{"STRAV", "STRAVEN", "STRAVN", "STRVN", "STRVNUE"}},
+		{"STREAM", "STRM", []string{"STREME"}},
+		{"STREET", "ST", []string{"STRT", "STR"}},
+		{"STREETS", "STS", nil},
+		{"SUMMIT", "SMT", []string{"SUMIT", "SUMITT"}},
+		{"TERRACE", "TER", []string{"TERR"}},
+		{"THROUGHWAY", "TRWY", nil},
+		{"TRACE", "TRCE", []string{"TRACES"}},
+		{"TRACK", "TRAK", []string{"TRACKS", "TRK", "TRKS"}},
+		{"TRAFFICWAY", "TRFY", nil},
+		{"TRAIL", "TRL", []string{"TRAILS", "TRLS"}},
+		{"TRAILER", "TRLR", []string{"TRLRS"}},
+		{"TUNNEL", "TUNL", []string{"TUNEL", "TUNLS", "TUNNELS", "TUNNL"}},
+		{"TURNPIKE", "TPKE", []string{"TRNPK", "TURNPK"}},
+		{"UNDERPASS", "UPAS", nil},
+		{"UNION", "UN", nil},
+		{"UNIONS", "UNS", nil},
+		{"VALLEY", "VLY", []string{"VALLY", "VLLY"}},
+		{"VALLEYS", "VLYS", nil},
+		{"VIADUCT", "VIA", []string{"VDCT", "VIADCT"}},
+		{"VIEW", "VW", nil},
+		{"VIEWS", "VWS", nil},
+		{"VILLAGE", "VLG", []string{"VILL", "VILLAG", "VILLG", "VILLIAGE"}},
+		{"VILLAGES", "VLGS", nil},
+		{"VILLE", "VL", nil},
+		{"VISTA", "VIS", []string{"VIST", "VST", "VSTA"}},
+		{"WALK", "WALK", []string{"WALKS"}},
+		{"WALL", "WALL", nil},
+		{"WAY", "WAY", []string{"WY"}},
+		{"WAYS", "WAYS", nil},
+		{"WELL", "WL", nil},
+		{"WELLS", "WLS", nil},
+	}
+
+	streetTypesByAlias = indexStreetSuffixes(streetSuffixes)
+)
+
+// indexStreetSuffixes maps each suffix's primary name, abbreviation and
+// aliases to the suffix.
+func indexStreetSuffixes(suffixes []*streetSuffix) map[string]*streetSuffix {
+	index := map[string]*streetSuffix{}
+	for _, suffix := range suffixes {
+		index[suffix.primary] = suffix
+		index[suffix.abbr] = suffix
+		for _, alias := range suffix.aliases {
+			index[alias] = suffix
+		}
+	}
+
+	return index
+}
+
+// strength ranks how surely alias is this street type. Abbreviations like
+// AVE are surest, then primary names like AVENUE, then words like PARK that
+// are spelled the same either way.
+func (s *streetSuffix) strength(alias string) int {
+	if s.primary == s.abbr {
+		return 1
+	} else if alias == s.primary {
+		return 2
+	}
+
+	return 3
+}
@@ -0,0 +1,156 @@
+package godress
+
+import "testing"
+
+func TestStreetTypeAliases(t *testing.T) {
+	tests := map[string][2]string{
+		"Street":    {"St", "Street"},
+		"Avenue":    {"Ave", "Avenue"},
+		"Av":        {"Ave", "Avenue"},
+		"Aven":      {"Ave", "Avenue"},
+		"Boul":      {"Blvd", "Boulevard"},
+		"Drv":       {"Dr", "Drive"},
+		"Crossroad": {"Xrd", "Crossroad"},
+		"Overpass":  {"Opas", "Overpass"},
+		"Underpass": {"Upas", "Underpass"},
+		"Motorway":  {"Mtwy", "Motorway"},
+		"Parkways":  {"Pkwy", "Parkway"},
+		"Ramp":      {"Ramp", "Ramp"},
+		"SKYWAY":    {"Skwy", "Skyway"},
+	}
+
+	for s, expected := range tests {
+		if !IsStreetType(s) {
+			t.Errorf("expected %s to be a street type", s)
+		}
+		if abbr := StreetTypeAbbr(s); abbr != expected[0] {
+			t.Errorf("%s: expected abbreviation %s, got %s", s, expected[0], abbr)
+		}
+		if name := StreetTypeName(s); name != expected[1] {
+			t.Errorf("%s: expected name %s, got %s", s, expected[1], name)
+		}
+	}
+
+	if IsStreetType("Gopher") || StreetTypeAbbr("Gopher") != "Gopher" {
+		t.Errorf("expected Gopher not to be a street type")
+	}
+}
+
+func TestParseStreetTypeSpellings(t *testing.T) {
+	for _, s := range []string{"123 Main Street Lehi, UT", "123 Main Str Lehi, UT", "123 Main Strt Lehi, UT"} {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if a.StreetName != "MAIN" || a.City != "LEHI" || StreetTypeAbbr(a.StreetType) != "St" {
+			t.Errorf("%s: expected MAIN St in LEHI, got %q %q in %q", s, a.StreetName, a.StreetType, a.City)
+		}
+	}
+
+	s, err := ParseStreet("12 Spring Lake Road")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.StreetName != "Spring Lake" || s.StreetType != "Road" {
+		t.Errorf("expected Spring Lake Road, got %q %q", s.StreetName, s.StreetType)
+	}
+}
+
+func TestParseDirectionals(t *testing.T) {
+	tests := map[string][4]string{
+		"123 N Main St Lehi, UT 84043":           {"N", "MAIN", "ST", ""},
+		"123 Main St N Lehi, UT 84043":           {"", "MAIN", "ST", "N"},
+		"123 North Main Street Lehi, UT 84043":   {"NORTH", "MAIN", "STREET", ""},
+		"77 Main St Southwest, Atlanta, GA":      {"", "MAIN", "ST", "SOUTHWEST"},
+		"100 N 200 E Lehi, UT 84043":             {"N", "200", "", "E"},
+		"123 N St, Boise, ID 83702":              {"", "N", "ST", ""},
+		"500 Key West Dr, Miami, FL 33101":       {"", "KEY WEST", "DR", ""},
+		"10 Elm St West Jordan, UT 84084":        {"", "ELM", "ST", ""},
+		"4500 W 200 S Apt 3, Salt Lake City, UT": {"W", "200", "", "S"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := [4]string{a.PreDirectional, a.StreetName, a.StreetType, a.PostDirectional}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	if !IsStreetDirection("Southwest") || StreetDirectionAbbr("Southwest") != "SW" {
+		t.Errorf("expected Southwest to be a street direction")
+	}
+
+	a := &Address{HouseNumber: "123", StreetName: "MAIN", StreetType: "ST", PostDirectional: "NW", City: "SEATTLE", State: "WA"}
+	if s := a.String(); s != "123 MAIN ST NW SEATTLE, WA" {
+		t.Errorf("expected post directional after the street type, got %q", s)
+	}
+}
+
+func TestParseStreetPreTypes(t *testing.T) {
+	tests := map[string][4]string{
+		"500 Avenue of the Americas, New York, NY 10036": {"AVENUE", "OF THE AMERICAS", "", "NEW YORK"},
+		"500 Avenue of the Americas New York NY 10036":   {"AVENUE", "OF THE AMERICAS", "", "NEW YORK"},
+		"500 Avenue of the Americas Brooklyn NY":         {"AVENUE", "OF THE AMERICAS", "", "BROOKLYN"},
+		"500 Avenue of the Americas":                     {"AVENUE", "OF THE AMERICAS", "", ""},
+		"500 Avenue B New York NY":                       {"AVENUE", "B", "", "NEW YORK"},
+		"12 Highway 101, Lehi, UT":                       {"HIGHWAY", "101", "", "LEHI"},
+		"4400 County Road 12, Lehi, UT 84043":            {"COUNTY ROAD", "12", "", "LEHI"},
+		"4400 CR 12, Lehi, UT 84043":                     {"COUNTY ROAD", "12", "", "LEHI"},
+		"77 State Route 9 Lehi, UT 84043":                {"STATE ROUTE", "9", "", "LEHI"},
+		"9 Interstate 35 Frontage Rd, Austin, TX 78701":  {"INTERSTATE", "35 FRONTAGE", "RD", "AUSTIN"},
+		"200 I-35, Austin, TX":                           {"INTERSTATE", "35", "", "AUSTIN"},
+		"100 US Hwy 1, Edison, NJ":                       {"US HIGHWAY", "1", "", "EDISON"},
+		"3 FM 1960, Houston, TX":                         {"FARM TO MARKET ROAD", "1960", "", "HOUSTON"},
+		"1 Calle Sol, Lehi, UT":                          {"CALLE", "SOL", "", "LEHI"},
+		"12 Ave Maria Dr, Lehi, UT":                      {"", "AVE MARIA", "DR", "LEHI"},
+	}
+
+	for s, expected := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		got := [4]string{a.StreetPreType, a.StreetName, a.StreetType, a.City}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, got)
+		}
+	}
+
+	s, err := ParseStreet("4400 Co Rd 12")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.StreetPreType != "County Road" || s.StreetName != "12" {
+		t.Errorf("expected County Road 12, got %q %q", s.StreetPreType, s.StreetName)
+	} else if str := s.String(); str != "4400 County Road 12" {
+		t.Errorf("expected 4400 County Road 12, got %q", str)
+	}
+}
+
+func TestParseGridAddresses(t *testing.T) {
+	for _, s := range []string{
+		"137 N 800 E Spanish Fork, UT 84660",
+		"137 North 800 East Spanish Fork, UT 84660",
+		"137 N 800 E St, Spanish Fork, UT 84660",
+		"137 North 800 East Street, Spanish Fork, UT 84660",
+	} {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if a.GridCoordinate != "800" || a.GridDirection != "E" || a.StreetType != "" || a.City != "SPANISH FORK" {
+			t.Errorf("%s: expected grid 800 E in SPANISH FORK, got %q %q %q in %q", s, a.GridCoordinate, a.GridDirection, a.StreetType, a.City)
+		} else if str := a.String(); str != "137 N 800 E SPANISH FORK, UT 84660" {
+			t.Errorf("%s: expected the canonical grid form, got %q", s, str)
+		}
+	}
+
+	a, err := Parse("100 N 200 E St George, UT 84770")
+	if err != nil {
+		t.Fatal(err)
+	} else if a.GridCoordinate != "200" || a.City != "ST GEORGE" {
+		t.Errorf("expected grid 200 in ST GEORGE, got %q in %q", a.GridCoordinate, a.City)
+	}
+}
@@ -2,30 +2,117 @@ package godress
 
 import "strings"
 
-// Term represents a company name term.
+// Term represents a secondary unit designator from USPS Publication 28
+// Appendix C2, i.e. Apt for Apartment.
 type Term struct {
 	Abbreviation string
 	Label        string
+	// NoRange is set for designators like Rear that aren't followed by
+	// a unit number.
+	NoRange bool
+}
+
+// SecondaryUnit is a unit within a building, i.e. APT 12.
+type SecondaryUnit struct {
+	Designator string `arango:"designator" json:"designator"`
+	Value      string `arango:"value,omitempty" json:"value,omitempty"`
 }
 
 var (
-	unitTerms = map[string]*Term{
-		"apt":   &Term{"Apt", "Apartment"},
-		"ste":   &Term{"Ste", "Suite"},
-		"suite": &Term{"Suite", "Suite"},
-		"unit":  &Term{"unit", "Unit"},
-		"#":     &Term{"#", "Number"},
+	secondaryUnitTerms = []*Term{
+		{"APT", "Apartment", false},
+		{"BSMT", "Basement", true},
+		{"BLDG", "Building", false},
+		{"DEPT", "Department", false},
+		{"FL", "Floor", false},
+		{"FRNT", "Front", true},
+		{"HNGR", "Hangar", false},
+		{"KEY", "Key", false},
+		{"LBBY", "Lobby", true},
+		{"LOT", "Lot", false},
+		{"LOWR", "Lower", true},
+		{"OFC", "Office", true},
+		{"PH", "Penthouse", true},
+		{"PIER", "Pier", false},
+		{"REAR", "Rear", true},
+		{"RM", "Room", false},
+		{"SIDE", "Side", true},
+		{"SLIP", "Slip", false},
+		{"SPC", "Space", false},
+		{"STOP", "Stop", false},
+		{"STE", "Suite", false},
+		{"TRLR", "Trailer", false},
+		{"UNIT", "Unit", false},
+		{"UPPR", "Upper", true},
+		{"#", "Number", false},
 	}
+
+	unitTerms = indexUnitTerms(secondaryUnitTerms)
 )
 
+// indexUnitTerms maps each term's abbreviation and label to the term.
+func indexUnitTerms(terms []*Term) map[string]*Term {
+	index := map[string]*Term{}
+	for _, term := range terms {
+		index[strings.ToUpper(term.Abbreviation)] = term
+		index[strings.ToUpper(term.Label)] = term
+	}
+
+	return index
+}
+
 // Scrub will remove any unit term from the addess.
 func ScrubUnit(address string) string {
-	addressX := strings.Split(strings.TrimSpace(address), " ")
-	for i, w := range addressX {
-		if _, contains := unitTerms[strings.ToLower(w)]; contains {
-			return ScrubUnit(strings.Join(append(addressX[:i]), " "))
+	words := strings.Fields(address)
+
+	var (
+		tokens []*token
+		wordOf []int
+	)
+	for i, w := range words {
+		for _, t := range tokenize(w) {
+			tokens = append(tokens, t)
+			wordOf = append(wordOf, i)
+		}
+	}
+
+	defaultParser.label(tokens, false, labelOptions{})
+	for i, t := range tokens {
+		if t.label == labelUnitDesignator {
+			return strings.Join(words[:wordOf[i]], " ")
 		}
 	}
 
-	return strings.Join(addressX, " ")
+	return strings.Join(words, " ")
+}
+
+// formatUnits formats secondary units the way they were written, i.e.
+// BLDG 3 APT 12, writing a unit without a designator as #12. The unit and
+// designator stand in for units when there are none.
+func formatUnits(units []SecondaryUnit, designator, unit string) string {
+	if len(units) == 0 && unit != "" {
+		units = []SecondaryUnit{{Designator: designator, Value: unit}}
+	}
+
+	parts := make([]string, 0, len(units))
+	for _, u := range units {
+		value := strings.TrimPrefix(u.Value, "#")
+		if u.Designator == "" || u.Designator == "#" {
+			parts = append(parts, "#"+value)
+		} else {
+			parts = append(parts, strings.TrimSpace(u.Designator+" "+value))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// isUnitValue reports whether s looks like the number of a unit,
+// i.e. 12, 4B or C.
+func isUnitValue(s string) bool {
+	if len(s) == 1 {
+		return true
+	}
+
+	return strings.ContainsAny(s, "0123456789")
 }
@@ -0,0 +1,61 @@
+package godress
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUnits(t *testing.T) {
+	tests := map[string][]SecondaryUnit{
+		"123 Main St Apt 200 Lehi, UT 84043":        {{"APT", "200"}},
+		"123 Main St Ste 200 Lehi, UT 84043":        {{"STE", "200"}},
+		"123 Main St Bldg 3 Apt 12 Lehi, UT 84043":  {{"BLDG", "3"}, {"APT", "12"}},
+		"123 Main St #4B Lehi, UT 84043":            {{"#", "4B"}},
+		"123 Main St Rear, Lehi, UT 84043":          {{"REAR", ""}},
+		"123 Main St Fl 2 Rm 210 Lehi, UT 84043":    {{"FL", "2"}, {"RM", "210"}},
+		"123 Pier Rd Lehi, UT 84043":                nil,
+		"123 Main St Penthouse, Lehi, UT 84043":     {{"PENTHOUSE", ""}},
+		"123 Main St Trlr 9 Spanish Fork, UT 84660": {{"TRLR", "9"}},
+	}
+
+	for s, units := range tests {
+		a, err := Parse(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(a.Units, units) {
+			t.Errorf("%s: expected units %v, got %v", s, units, a.Units)
+		}
+
+		if len(units) > 0 && (a.UnitDesignator != units[len(units)-1].Designator || a.Unit != units[len(units)-1].Value) {
+			t.Errorf("%s: expected the last unit in UnitDesignator and Unit, got %q %q", s, a.UnitDesignator, a.Unit)
+		}
+	}
+
+	s, err := ParseStreet("123 Main St Bldg 3 Apt 12")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.StreetName != "Main" || s.UnitDesignator != "Apt" || s.Unit != "12" || len(s.Units) != 2 {
+		t.Errorf("expected Main with two units, got %+v", s)
+	}
+}
+
+func TestScrubUnit(t *testing.T) {
+	tests := map[string]string{
+		"123 Main St Apt 4":   "123 Main St",
+		"123 Main St Ste 200": "123 Main St",
+		"123 Pier Rd":         "123 Pier Rd",
+	}
+
+	for s, expected := range tests {
+		if scrubbed := ScrubUnit(s); scrubbed != expected {
+			t.Errorf("%s: expected %q, got %q", s, expected, scrubbed)
+		}
+
+		if IsApartment(s) != (s != expected) {
+			t.Errorf("%s: expected IsApartment to be %v", s, s != expected)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package godress
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is how serious a validation issue is.
+type Severity int
+
+const (
+	// SeverityInfo is worth knowing but doesn't make the address wrong.
+	SeverityInfo Severity = iota + 1
+	// SeverityWarning is likely to delay delivery.
+	SeverityWarning
+	// SeverityError makes the address undeliverable as is.
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// String returns a human readable description of the severity.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// IssueKind identifies what is wrong with an address.
+type IssueKind int
+
+const (
+	// IssueMissingField means a required field is empty.
+	IssueMissingField IssueKind = iota + 1
+	// IssueUnknownState means the state isn't a known state.
+	IssueUnknownState
+	// IssueInvalidZip means the zip code isn't shaped like one.
+	IssueInvalidZip
+	// IssueZipStateMismatch means the zip code isn't in the state.
+	IssueZipStateMismatch
+	// IssueCityZipMismatch means the city isn't one of the zip code's cities.
+	IssueCityZipMismatch
+	// IssueUnknownZip means the zip code isn't in the zip code data. It is
+	// only reported once a full dataset is loaded with LoadZipData.
+	IssueUnknownZip
+)
+
+var issueKindNames = map[IssueKind]string{
+	IssueMissingField:     "missing field",
+	IssueUnknownState:     "unknown state",
+	IssueInvalidZip:       "invalid zip code",
+	IssueZipStateMismatch: "zip code not in state",
+	IssueCityZipMismatch:  "city not in zip code",
+	IssueUnknownZip:       "unknown zip code",
+}
+
+// String returns a human readable description of the kind.
+func (k IssueKind) String() string {
+	if name, ok := issueKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("IssueKind(%d)", int(k))
+}
+
+// Issue is a problem found by Validate.
+type Issue struct {
+	Kind     IssueKind `json:"kind"`
+	Severity Severity  `json:"severity"`
+	Field    Field     `json:"field"`
+	Value    string    `json:"value,omitempty"`
+}
+
+// String describes the issue.
+func (i Issue) String() string {
+	if i.Value == "" {
+		return fmt.Sprintf("%s: %s %s", i.Severity, i.Kind, i.Field)
+	}
+
+	return fmt.Sprintf("%s: %s %q", i.Severity, i.Kind, i.Value)
+}
+
+// Validate checks that an address has the fields it needs to be delivered
+// and that its city, state and zip code agree, going by the first 3 digits
+// of the zip code and the zip code data. A zip code missing from the
+// embedded sample data is taken as no data, not as an issue. The issues
+// found are returned most severe first.
+func Validate(a *Address) (issues []Issue) {
+	add := func(kind IssueKind, severity Severity, field Field, value string) {
+		issues = append(issues, Issue{Kind: kind, Severity: severity, Field: field, Value: value})
+	}
+
+	switch {
+	case a.Kind.isRoute() && a.Box == "":
+		add(IssueMissingField, SeverityError, FieldBox, "")
+	case a.Kind == KindMilitary && a.MilitaryUnit == "":
+		add(IssueMissingField, SeverityError, FieldMilitaryUnit, "")
+	case a.Kind == "" || a.Kind.hasStreet():
+		if a.HouseNumber == "" {
+			add(IssueMissingField, SeverityError, FieldHouseNumber, "")
+		}
+		if a.StreetName == "" {
+			add(IssueMissingField, SeverityError, FieldStreetName, "")
+		}
+	}
+
+	// A zip code is enough to deliver to, a city and state without one
+	// are slower.
+	switch {
+	case a.PostalCode == "" && (a.City == "" || a.State == ""):
+		add(IssueMissingField, SeverityError, FieldPostalCode, "")
+	case a.PostalCode == "":
+		add(IssueMissingField, SeverityWarning, FieldPostalCode, "")
+	}
+	if a.City == "" && a.PostalCode != "" {
+		add(IssueMissingField, SeverityWarning, FieldCity, "")
+	}
+	if a.State == "" && a.PostalCode != "" {
+		add(IssueMissingField, SeverityWarning, FieldState, "")
+	}
+
+	state := StateAbbreviation(a.State)
+	validState := state != "" && IsState(state)
+	if a.State != "" && !validState {
+		add(IssueUnknownState, SeverityError, FieldState, a.State)
+	}
+
+	if a.PostalCode == "" {
+		return sortIssues(issues)
+	} else if !IsZipcode(a.PostalCode) {
+		add(IssueInvalidZip, SeverityError, FieldPostalCode, a.PostalCode)
+		return sortIssues(issues)
+	}
+
+	if validState && !containsFold(ZipStates(a.PostalCode), state) {
+		add(IssueZipStateMismatch, SeverityError, FieldPostalCode, a.PostalCode)
+	}
+
+	info, ok := LookupZip(a.PostalCode)
+	if !ok && !zipDataIsSample() {
+		add(IssueUnknownZip, SeverityInfo, FieldPostalCode, a.PostalCode)
+	} else if ok && a.City != "" && !zipHasCity(info, a.City) {
+		add(IssueCityZipMismatch, SeverityWarning, FieldCity, a.City)
+	}
+
+	return sortIssues(issues)
+}
+
+// Validate checks the address like the package level Validate.
+func (a *Address) Validate() []Issue {
+	return Validate(a)
+}
+
+// HasErrors reports whether any of the issues is an error.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortIssues orders issues most severe first, keeping the order they were
+// found in otherwise.
+func sortIssues(issues []Issue) []Issue {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	sorted := make([]Issue, 0, len(issues))
+	for severity := SeverityError; severity >= SeverityInfo; severity-- {
+		for _, issue := range issues {
+			if issue.Severity == severity {
+				sorted = append(sorted, issue)
+			}
+		}
+	}
+
+	return sorted
+}
+
+// zipHasCity reports whether city is the primary or an acceptable city of
+// the zip code.
+func zipHasCity(info *ZipInfo, city string) bool {
+	return containsFold(append([]string{info.PrimaryCity}, info.AcceptableCities...), strings.Join(strings.Fields(city), " "))
+}
+
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package godress
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	tests := map[string][]Issue{
+		"123 Main St Lehi, UT 84043": nil,
+		"123 Main St Lehi, CA 84043": {
+			{Kind: IssueZipStateMismatch, Severity: SeverityError, Field: FieldPostalCode, Value: "84043"},
+		},
+		"123 Main St Provo, CA 84043": {
+			{Kind: IssueZipStateMismatch, Severity: SeverityError, Field: FieldPostalCode, Value: "84043"},
+			{Kind: IssueCityZipMismatch, Severity: SeverityWarning, Field: FieldCity, Value: "PROVO"},
+		},
+		"123 Main St Provo, UT 84043": {
+			{Kind: IssueCityZipMismatch, Severity: SeverityWarning, Field: FieldCity, Value: "PROVO"},
+		},
+		"123 Main St Lehi, UT": {
+			{Kind: IssueMissingField, Severity: SeverityWarning, Field: FieldPostalCode},
+		},
+		"123 Main St 84043": {
+			{Kind: IssueMissingField, Severity: SeverityWarning, Field: FieldCity},
+			{Kind: IssueMissingField, Severity: SeverityWarning, Field: FieldState},
+		},
+		"123 Main St Ogden, UT 84401":                    nil,
+		"350 5th Ave, New York, NY 10118":                nil,
+		"123 Main St, St George, UT 84770":               nil,
+		"123 Main St, Saint George, UT 84770":            nil,
+		"1 Calle Sol, San Juan, PR 00901":                nil,
+		"PSC 1234 Box 5678 APO AE 09204":                 nil,
+		"1 Main St, Pago Pago, AS 96799":                 nil,
+		"1600 Pennsylvania Ave NW, Washington, DC 20500": nil,
+	}
+
+	for s, expected := range tests {
+		a, _ := Parse(s)
+		issues := Validate(a)
+		if len(issues) != len(expected) {
+			t.Errorf("%s: expected %v, got %v", s, expected, issues)
+			continue
+		}
+		for i := range issues {
+			if issues[i] != expected[i] {
+				t.Errorf("%s: expected %v, got %v", s, expected, issues)
+				break
+			}
+		}
+	}
+
+	a := &Address{HouseNumber: "123", City: "Lehi", State: "XX", PostalCode: "8404"}
+	issues := a.Validate()
+	if !HasErrors(issues) || len(issues) != 3 {
+		t.Fatalf("expected 3 errors, got %v", issues)
+	}
+	for i, kind := range []IssueKind{IssueMissingField, IssueUnknownState, IssueInvalidZip} {
+		if issues[i].Kind != kind {
+			t.Errorf("expected %s, got %s", kind, issues[i].Kind)
+		}
+	}
+
+	if issues := Validate(&Address{HouseNumber: "1", StreetName: "MAIN"}); !HasErrors(issues) {
+		t.Errorf("expected an address without a zip code, city or state to be an error")
+	}
+}
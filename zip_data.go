@@ -0,0 +1,217 @@
+package godress
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ZipDataVersion is the version of the embedded zip code data. The
+// embedded data is a hand-picked sample of zip codes for examples and
+// tests, not reference data: most zip codes aren't in it. Until
+// LoadZipData replaces it with a full dataset in the same format, a zip
+// code or city missing from it is unknown rather than wrong.
+const ZipDataVersion = "2024.06-sample.1"
+
+// ZipType is the kind of delivery a zip code is for.
+type ZipType string
+
+// Zip code types.
+const (
+	ZipStandard ZipType = "standard"
+	ZipPoBox    ZipType = "po_box"
+	ZipUnique   ZipType = "unique"
+	ZipMilitary ZipType = "military"
+)
+
+// ZipInfo is what is known about a zip code.
+type ZipInfo struct {
+	Zip              string   `json:"zip"`
+	Type             ZipType  `json:"type"`
+	PrimaryCity      string   `json:"primary_city"`
+	AcceptableCities []string `json:"acceptable_cities,omitempty"`
+	State            string   `json:"state"`
+	County           string   `json:"county,omitempty"`
+	CountyFIPS       string   `json:"county_fips,omitempty"`
+	Latitude         float64  `json:"latitude,omitempty"`
+	Longitude        float64  `json:"longitude,omitempty"`
+	TimeZone         string   `json:"time_zone,omitempty"`
+}
+
+// zipData indexes the zip code data by zip code, and by city and state.
+// sample is set for the embedded data, which leaves out most zip codes.
+type zipData struct {
+	byZip  map[string]*ZipInfo
+	byCity map[string][]*ZipInfo
+	sample bool
+}
+
+var (
+	//go:embed data/zipcodes_sample.csv
+	embeddedZipData string
+
+	zipDataOnce sync.Once
+	zipDataMu   sync.RWMutex
+	zips        *zipData
+)
+
+// LoadZipData replaces the zip code data with the csv read from r, which
+// is taken to have every zip code. It has the columns of
+// data/zipcodes_sample.csv: zip, type, primary_city,
+// acceptable_cities (separated by semicolons), state, county, county_fips,
+// latitude, longitude and timezone.
+func LoadZipData(r io.Reader) error {
+	data, err := readZipData(r)
+	if err != nil {
+		return err
+	}
+
+	zipDataOnce.Do(func() {})
+	zipDataMu.Lock()
+	zips = data
+	zipDataMu.Unlock()
+
+	return nil
+}
+
+// LookupZip finds what is known about a zip code, which may have its +4
+// add-on. With the embedded sample data, a zip code that isn't found may
+// still exist.
+func LookupZip(zip string) (*ZipInfo, bool) {
+	zip, _, _ = splitZipcode(strings.TrimSpace(zip))
+	info, ok := loadedZipData().byZip[zip]
+
+	return info, ok
+}
+
+// ZipsForCity returns the zip codes of a city, as its primary or an
+// acceptable city name, in order.
+func ZipsForCity(city, state string) []*ZipInfo {
+	return loadedZipData().byCity[cityKey(city, StateAbbreviation(state))]
+}
+
+// Enrich fills in the county and coordinates of an address from its zip
+// code, along with its city and state when they are missing, which are
+// added to Inferred. It reports whether the zip code was found.
+func Enrich(a *Address) bool {
+	info, ok := LookupZip(a.PostalCode)
+	if !ok {
+		return false
+	}
+
+	completeFromZip(a, info)
+	if a.County == "" {
+		a.County = info.County
+	}
+	if a.Latitude == 0 && a.Longitude == 0 {
+		a.Latitude, a.Longitude = info.Latitude, info.Longitude
+	}
+
+	return true
+}
+
+// cityNames returns the names of the cities of a state in the zip code
+// data, or of every state when state is empty.
+func cityNames(state string) []string {
+	var names []string
+	for key := range loadedZipData().byCity {
+		i := strings.LastIndex(key, "|")
+		if state == "" || key[i+1:] == strings.ToUpper(state) {
+			names = append(names, key[:i])
+		}
+	}
+
+	return names
+}
+
+// zipDataIsSample reports whether the zip code data is the embedded
+// sample, in which missing zip codes and cities tell nothing.
+func zipDataIsSample() bool {
+	return loadedZipData().sample
+}
+
+func loadedZipData() *zipData {
+	zipDataOnce.Do(func() {
+		data, err := readSampleZipData()
+		if err != nil {
+			panic(fmt.Sprintf("godress: embedded zip code data: %v", err))
+		}
+		zips = data
+	})
+
+	zipDataMu.RLock()
+	defer zipDataMu.RUnlock()
+
+	return zips
+}
+
+// readSampleZipData reads the embedded sample zip code data.
+func readSampleZipData() (*zipData, error) {
+	data, err := readZipData(strings.NewReader(embeddedZipData))
+	if err != nil {
+		return nil, err
+	}
+	data.sample = true
+
+	return data, nil
+}
+
+func readZipData(r io.Reader) (*zipData, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	} else if len(records) == 0 {
+		return nil, fmt.Errorf("godress: no zip code data")
+	}
+
+	data := &zipData{byZip: map[string]*ZipInfo{}, byCity: map[string][]*ZipInfo{}}
+	for n, record := range records[1:] {
+		if len(record) != 10 {
+			return nil, fmt.Errorf("godress: zip code data line %d has %d columns, want 10", n+2, len(record))
+		}
+
+		info := &ZipInfo{
+			Zip:         record[0],
+			Type:        ZipType(record[1]),
+			PrimaryCity: strings.ToUpper(record[2]),
+			State:       strings.ToUpper(record[4]),
+			County:      strings.ToUpper(record[5]),
+			CountyFIPS:  record[6],
+			TimeZone:    record[9],
+		}
+		for _, city := range strings.Split(record[3], ";") {
+			if city = strings.TrimSpace(city); city != "" {
+				info.AcceptableCities = append(info.AcceptableCities, strings.ToUpper(city))
+			}
+		}
+		if record[7] != "" || record[8] != "" {
+			if info.Latitude, err = strconv.ParseFloat(record[7], 64); err != nil {
+				return nil, fmt.Errorf("godress: zip code data line %d: %v", n+2, err)
+			}
+			if info.Longitude, err = strconv.ParseFloat(record[8], 64); err != nil {
+				return nil, fmt.Errorf("godress: zip code data line %d: %v", n+2, err)
+			}
+		}
+
+		data.byZip[info.Zip] = info
+		for _, city := range append([]string{info.PrimaryCity}, info.AcceptableCities...) {
+			key := cityKey(city, info.State)
+			data.byCity[key] = append(data.byCity[key], info)
+		}
+	}
+
+	for _, infos := range data.byCity {
+		sort.Slice(infos, func(i, j int) bool { return infos[i].Zip < infos[j].Zip })
+	}
+
+	return data, nil
+}
+
+func cityKey(city, state string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(city), " ")) + "|" + strings.ToUpper(state)
+}
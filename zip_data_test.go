@@ -0,0 +1,87 @@
+package godress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupZip(t *testing.T) {
+	info, ok := LookupZip("84043-1234")
+	if !ok {
+		t.Fatal("expected 84043 to be found")
+	} else if info.PrimaryCity != "LEHI" || info.State != "UT" || info.CountyFIPS != "49049" || info.Type != ZipStandard || info.TimeZone != "America/Denver" {
+		t.Errorf("unexpected 84043: %+v", info)
+	}
+
+	for zip, typ := range map[string]ZipType{"00501": ZipUnique, "33101": ZipPoBox, "09204": ZipMilitary} {
+		if info, ok := LookupZip(zip); !ok || info.Type != typ {
+			t.Errorf("%s: expected %s, got %+v", zip, typ, info)
+		}
+	}
+
+	if _, ok := LookupZip("00000"); ok {
+		t.Errorf("expected 00000 not to be found")
+	}
+
+	var found []string
+	for _, info := range ZipsForCity("salt lake city", "Utah") {
+		found = append(found, info.Zip)
+	}
+	if strings.Join(found, " ") != "84101 84104 84111" {
+		t.Errorf("expected the zip codes of Salt Lake City, got %v", found)
+	}
+	if len(ZipsForCity("Saint George", "UT")) != 1 {
+		t.Errorf("expected acceptable city names to be found")
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	a, err := NewParser(WithEnrichment()).Parse("123 Main St 84660")
+	if err != nil {
+		t.Fatal(err)
+	} else if a.City != "SPANISH FORK" || a.State != "UT" || a.County != "UTAH COUNTY" || a.Latitude == 0 || a.Longitude == 0 {
+		t.Errorf("expected an enriched address, got %+v", a)
+	}
+
+	a = &Address{City: "Lehi", PostalCode: "99999"}
+	if Enrich(a) || a.County != "" {
+		t.Errorf("expected an unknown zip code not to enrich, got %+v", a)
+	}
+}
+
+func TestLoadZipData(t *testing.T) {
+	defer func() {
+		data, err := readSampleZipData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		zipDataMu.Lock()
+		zips = data
+		zipDataMu.Unlock()
+	}()
+
+	data := "zip,type,primary_city,acceptable_cities,state,county,county_fips,latitude,longitude,timezone\n" +
+		"12345,unique,Schenectady,,NY,Schenectady County,36093,42.81,-73.94,America/New_York\n"
+	if err := LoadZipData(strings.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, ok := LookupZip("12345"); !ok || info.PrimaryCity != "SCHENECTADY" {
+		t.Errorf("expected the loaded data, got %+v", info)
+	} else if _, ok := LookupZip("84043"); ok {
+		t.Errorf("expected the embedded data to be replaced")
+	}
+
+	a := &Address{HouseNumber: "123", StreetName: "Main", City: "Schenectady", State: "NY"}
+	if !Complete(a) || a.PostalCode != "12345" {
+		t.Errorf("expected a full dataset to fill in the only zip code, got %q", a.PostalCode)
+	}
+	a = &Address{HouseNumber: "123", StreetName: "Main", City: "Lehi", State: "UT", PostalCode: "84043"}
+	if issues := Validate(a); len(issues) != 1 || issues[0].Kind != IssueUnknownZip {
+		t.Errorf("expected a full dataset to report unknown zip codes, got %v", issues)
+	}
+
+	if err := LoadZipData(strings.NewReader("zip,type\n12345,unique\n")); err == nil {
+		t.Errorf("expected an error for missing columns")
+	}
+}